@@ -0,0 +1,121 @@
+package main
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// AccessLogOptions configures the HTTP access logging middleware.
+type AccessLogOptions struct {
+	Enabled      bool
+	Format       string
+	ExcludePaths []string
+}
+
+// statusRecorder wraps a http.ResponseWriter to keep track of the status code and the size of the response.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += n
+
+	return n, err
+}
+
+// NewAccessLogger returns the logger used to write the access log in the requested format.
+func NewAccessLogger(format string) log.Logger {
+	var logger log.Logger
+
+	switch format {
+	case "json":
+		logger = log.NewJSONLogger(log.NewSyncWriter(os.Stdout))
+	default:
+		logger = log.NewLogfmtLogger(log.NewSyncWriter(os.Stdout))
+	}
+
+	return log.With(logger, "ts", log.DefaultTimestampUTC)
+}
+
+// AccessLogHandler instruments every request served by the given mux with a request counter and a duration histogram,
+// and writes an access log line per request when enabled.
+func AccessLogHandler(mux *http.ServeMux, registry prometheus.Registerer, options AccessLogOptions) http.Handler {
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "scaleway_http_requests_total",
+		Help: "The total number of HTTP requests served by the exporter",
+	}, []string{"handler", "method", "code"})
+
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "scaleway_http_request_duration_seconds",
+		Help:    "The duration of HTTP requests served by the exporter",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"handler", "method"})
+
+	registry.MustRegister(requests, duration)
+
+	excluded := make(map[string]bool, len(options.ExcludePaths))
+
+	for _, path := range options.ExcludePaths {
+		excluded[path] = true
+	}
+
+	var logger log.Logger
+
+	if options.Enabled {
+		logger = NewAccessLogger(options.Format)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		recorder := &statusRecorder{ResponseWriter: w}
+
+		// resolve the registered pattern to avoid exploding the cardinality with arbitrary paths
+		_, handler := mux.Handler(r)
+
+		mux.ServeHTTP(recorder, r)
+
+		if recorder.status == 0 {
+			recorder.status = http.StatusOK
+		}
+
+		elapsed := time.Since(start)
+
+		requests.WithLabelValues(handler, r.Method, strconv.Itoa(recorder.status)).Inc()
+		duration.WithLabelValues(handler, r.Method).Observe(elapsed.Seconds())
+
+		if logger == nil || excluded[r.URL.Path] {
+			return
+		}
+
+		_ = logger.Log(
+			"remote_addr", r.RemoteAddr,
+			"forwarded_for", r.Header.Get("X-Forwarded-For"),
+			"method", r.Method,
+			"path", r.URL.Path,
+			"query", r.URL.RawQuery,
+			"proto", r.Proto,
+			"status", recorder.status,
+			"bytes", recorder.bytes,
+			"duration", elapsed,
+			"user_agent", r.UserAgent(),
+		)
+	})
+}
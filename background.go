@@ -0,0 +1,84 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// BackgroundGatherer gathers the metrics on its own schedule and serves the last snapshot,
+// so the scrapes are answered instantly even when the Scaleway API is slow.
+type BackgroundGatherer struct {
+	logger   log.Logger
+	gatherer prometheus.Gatherer
+	interval time.Duration
+	mutex    sync.RWMutex
+	families []*dto.MetricFamily
+	err      error
+
+	Duration  prometheus.Gauge
+	Timestamp prometheus.Gauge
+}
+
+// NewBackgroundGatherer returns a new BackgroundGatherer of the given gatherer.
+func NewBackgroundGatherer(logger log.Logger, gatherer prometheus.Gatherer, interval time.Duration) *BackgroundGatherer {
+	return &BackgroundGatherer{
+		logger:   logger,
+		gatherer: gatherer,
+		interval: interval,
+
+		Duration: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "scaleway_background_collection_duration_seconds",
+			Help: "The duration of the last background collection",
+		}),
+		Timestamp: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "scaleway_background_collection_timestamp_seconds",
+			Help: "Timestamp of the end of the last background collection",
+		}),
+	}
+}
+
+// Run collects the metrics right away and then at every interval, it never returns.
+func (g *BackgroundGatherer) Run() {
+	ticker := time.NewTicker(g.interval)
+	defer ticker.Stop()
+
+	for {
+		g.Collect()
+
+		<-ticker.C
+	}
+}
+
+// Collect gathers the metrics and replaces the snapshot.
+func (g *BackgroundGatherer) Collect() {
+	start := time.Now()
+
+	families, err := g.gatherer.Gather()
+
+	if err != nil {
+		_ = level.Warn(g.logger).Log("msg", "the background collection failed", "err", err)
+	}
+
+	g.mutex.Lock()
+	g.families = families
+	g.err = err
+	g.mutex.Unlock()
+
+	g.Duration.Set(time.Since(start).Seconds())
+	g.Timestamp.Set(float64(time.Now().Unix()))
+
+	_ = level.Debug(g.logger).Log("msg", "background collection done", "duration", time.Since(start))
+}
+
+// Gather implements the prometheus.Gatherer interface, it returns the last snapshot.
+func (g *BackgroundGatherer) Gather() ([]*dto.MetricFamily, error) {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	return g.families, g.err
+}
@@ -0,0 +1,150 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	applesilicon "github.com/scaleway/scaleway-sdk-go/api/applesilicon/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// AppleSiliconCollector collects metrics about all Apple Silicon servers.
+type AppleSiliconCollector struct {
+	logger             log.Logger
+	errors             *prometheus.CounterVec
+	client             *scw.Client
+	appleSiliconClient *applesilicon.API
+	timeout            time.Duration
+	zones              []scw.Zone
+	projects           ProjectFilter
+
+	Up          *prometheus.Desc
+	DeletableAt *prometheus.Desc
+}
+
+// NewAppleSiliconCollector returns a new AppleSiliconCollector.
+func NewAppleSiliconCollector(logger log.Logger, errors *prometheus.CounterVec, client *scw.Client, timeout time.Duration, zones []scw.Zone, projects ProjectFilter) *AppleSiliconCollector {
+	errors.WithLabelValues("applesilicon").Add(0)
+
+	_ = level.Info(logger).Log("msg", "Apple Silicon collector enabled")
+
+	return &AppleSiliconCollector{
+		logger:             logger,
+		errors:             errors,
+		client:             client,
+		appleSiliconClient: applesilicon.NewAPI(client),
+		timeout:            timeout,
+		zones:              zones,
+		projects:           projects,
+
+		Up: prometheus.NewDesc(
+			"scaleway_applesilicon_server_up",
+			"If 1 the server is ready, 0.5 when starting, rebooting, updating or reinstalling, 0 otherwise",
+			[]string{"id", "name", "zone", "project_id", "type", "os", "os_version", "status"}, nil,
+		),
+		DeletableAt: prometheus.NewDesc(
+			"scaleway_applesilicon_server_deletable_at_timestamp_seconds",
+			"Timestamp from which the server can be deleted",
+			[]string{"id", "name", "zone"}, nil,
+		),
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+// collected by this Collector.
+func (c *AppleSiliconCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Up
+	ch <- c.DeletableAt
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *AppleSiliconCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	for _, zone := range c.zones {
+		response, err := c.appleSiliconClient.ListServers(&applesilicon.ListServersRequest{Zone: zone}, scw.WithAllPages(), scw.WithContext(ctx))
+
+		if err != nil {
+			var responseError *scw.ResponseError
+
+			switch {
+			case errors.As(err, &responseError) && (responseError.StatusCode == http.StatusNotImplemented || responseError.StatusCode == http.StatusNotFound):
+				_ = level.Debug(c.logger).Log("msg", "Apple Silicon is not supported in this zone", "zone", zone)
+			default:
+				c.errors.WithLabelValues("applesilicon").Add(1)
+				_ = level.Warn(c.logger).Log("msg", "can't fetch the list of Apple Silicon servers", "err", err, "zone", zone)
+			}
+
+			continue
+		}
+
+		_ = level.Debug(c.logger).Log("msg", fmt.Sprintf("found %d Apple Silicon servers", len(response.Servers)), "zone", zone)
+
+		for _, server := range response.Servers {
+			if !c.projects.Match(server.ProjectID) {
+				continue
+			}
+
+			c.CollectServer(ch, server)
+		}
+	}
+}
+
+func (c *AppleSiliconCollector) CollectServer(ch chan<- prometheus.Metric, server *applesilicon.Server) {
+	var osName, osVersion string
+
+	if server.Os != nil {
+		osName = server.Os.Name
+		osVersion = server.Os.Version
+	}
+
+	var active float64
+
+	switch server.Status {
+	case applesilicon.ServerStatusReady:
+		active = 1.0
+	case applesilicon.ServerStatusStarting:
+		active = 0.5
+	case applesilicon.ServerStatusRebooting:
+		active = 0.5
+	case applesilicon.ServerStatusUpdating:
+		active = 0.5
+	case applesilicon.ServerStatusReinstalling:
+		active = 0.5
+	case applesilicon.ServerStatusLocking:
+		active = 0.0
+	case applesilicon.ServerStatusLocked:
+		active = 0.0
+	case applesilicon.ServerStatusUnlocking:
+		active = 0.0
+	case applesilicon.ServerStatusError:
+		active = 0.0
+	case applesilicon.ServerStatusUnknownStatus:
+		active = 0.0
+	default:
+		active = 0.0
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.Up,
+		prometheus.GaugeValue,
+		active,
+		server.ID, server.Name, server.Zone.String(), server.ProjectID, server.Type, osName, osVersion, server.Status.String(),
+	)
+
+	if server.DeletableAt != nil {
+		ch <- prometheus.MustNewConstMetric(
+			c.DeletableAt,
+			prometheus.GaugeValue,
+			float64(server.DeletableAt.Unix()),
+			server.ID, server.Name, server.Zone.String(),
+		)
+	}
+}
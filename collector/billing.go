@@ -2,54 +2,162 @@ package collector
 
 import (
 	"context"
-	"net/http"
-	"net/url"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/scaleway/scaleway-sdk-go/api/account/v2"
+	billing "github.com/scaleway/scaleway-sdk-go/api/billing/v2beta1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
+// BillingOptions are the optional settings of the BillingCollector.
+type BillingOptions struct {
+	// Currency into which the consumptions are converted, nothing is converted when empty.
+	Currency string
+	Rates    CurrencyRates
+	Budgets  []*Budget
+}
+
 // BillingCollector collects metrics about all buckets.
 type BillingCollector struct {
-	logger         log.Logger
-	errors         *prometheus.CounterVec
-	timeout        time.Duration
-	client         *scw.Client
-	accountClient  *account.API
-	organizationID string
-
-	Consumptions *prometheus.Desc
-	Update       *prometheus.Desc
+	logger          log.Logger
+	errors          *prometheus.CounterVec
+	timeout         time.Duration
+	client          *scw.Client
+	accountClient   *account.API
+	billingClient   *billing.API
+	organizationIDs []string
+	options         BillingOptions
+
+	Consumptions           *prometheus.Desc
+	ConsumptionsNormalized *prometheus.Desc
+	ConsumptionQuantity    *prometheus.Desc
+	CategoryConsumption    *prometheus.Desc
+	ProjectConsumption     *prometheus.Desc
+	Forecast               *prometheus.Desc
+	BudgetLimit            *prometheus.Desc
+	BudgetUsageRatio       *prometheus.Desc
+	Update                 *prometheus.Desc
+	InvoiceTotalTaxed      *prometheus.Desc
+	InvoiceTotalUntaxed    *prometheus.Desc
+	InvoiceIssuedAt        *prometheus.Desc
+	InvoiceDueAt           *prometheus.Desc
+	DiscountRemaining      *prometheus.Desc
+	DiscountExpiresAt      *prometheus.Desc
 }
 
-// NewBillingCollector returns a new BucketCollector.
-func NewBillingCollector(logger log.Logger, errors *prometheus.CounterVec, client *scw.Client, timeout time.Duration, organizationID string) *BillingCollector {
-	errors.WithLabelValues("bucket").Add(0)
+// NewBillingCollector returns a new BillingCollector.
+func NewBillingCollector(logger log.Logger, errors *prometheus.CounterVec, client *scw.Client, timeout time.Duration, organizationIDs []string, options BillingOptions) *BillingCollector {
+	errors.WithLabelValues("billing").Add(0)
 
 	_ = level.Info(logger).Log("msg", "Billing collector enabled")
 
+	labelsConsumption := []string{"organization_id", "project_id", "project_name", "category", "product_name", "resource_name", "sku", "period", "period_start"}
+
+	labelsInvoice := []string{"organization_id", "id", "number", "type", "state", "period"}
+
+	labelsDiscount := []string{"organization_id", "id", "description", "mode", "scope"}
+
 	return &BillingCollector{
-		logger:         logger,
-		errors:         errors,
-		timeout:        timeout,
-		client:         client,
-		accountClient:  account.NewAPI(client),
-		organizationID: organizationID,
+		logger:          logger,
+		errors:          errors,
+		timeout:         timeout,
+		client:          client,
+		accountClient:   account.NewAPI(client),
+		billingClient:   billing.NewAPI(client),
+		organizationIDs: organizationIDs,
+		options:         options,
 
 		Consumptions: prometheus.NewDesc(
 			"scaleway_billing_consumptions",
-			"Consumptions",
-			[]string{"project_id", "project_name", "category", "operation_path", "description", "currency_code"}, nil,
+			"Consumptions of the current or previous billing period",
+			append(append([]string{}, labelsConsumption...), "currency_code"), nil,
+		),
+
+		ConsumptionsNormalized: prometheus.NewDesc(
+			"scaleway_billing_consumptions_normalized",
+			"Consumptions of the current or previous billing period converted into the configured billing currency",
+			append(append([]string{}, labelsConsumption...), "currency_code"), nil,
+		),
+
+		ConsumptionQuantity: prometheus.NewDesc(
+			"scaleway_billing_consumption_quantity",
+			"Billed quantity of the consumptions of the current or previous billing period",
+			append(append([]string{}, labelsConsumption...), "unit"), nil,
+		),
+
+		CategoryConsumption: prometheus.NewDesc(
+			"scaleway_billing_category_consumptions",
+			"Consumptions of the current or previous billing period summed by category",
+			[]string{"organization_id", "category", "period", "period_start", "currency_code"}, nil,
+		),
+
+		ProjectConsumption: prometheus.NewDesc(
+			"scaleway_billing_project_consumptions",
+			"Consumptions of the current or previous billing period summed by project",
+			[]string{"organization_id", "project_id", "project_name", "period", "period_start", "currency_code"}, nil,
+		),
+
+		Forecast: prometheus.NewDesc(
+			"scaleway_billing_forecast_monthly_cost",
+			"Projected cost of the current billing period, extrapolated from the consumptions and the elapsed fraction of the period",
+			[]string{"organization_id", "currency_code"}, nil,
+		),
+
+		BudgetLimit: prometheus.NewDesc(
+			"scaleway_billing_budget_limit",
+			"The monthly limit of the configured budget",
+			[]string{"organization_id", "budget", "project_id", "category", "currency_code"}, nil,
+		),
+
+		BudgetUsageRatio: prometheus.NewDesc(
+			"scaleway_billing_budget_usage_ratio",
+			"The ratio of the configured budget consumed during the current billing period",
+			[]string{"organization_id", "budget", "project_id", "category", "currency_code"}, nil,
 		),
 
 		Update: prometheus.NewDesc(
 			"scaleway_billing_update_timestamp_seconds",
 			"Timestamp of the last update",
-			nil, nil,
+			[]string{"organization_id"}, nil,
+		),
+
+		InvoiceTotalTaxed: prometheus.NewDesc(
+			"scaleway_billing_invoice_total_taxed",
+			"The total amount of the invoice, taxes included",
+			append(append([]string{}, labelsInvoice...), "currency_code"), nil,
+		),
+		InvoiceTotalUntaxed: prometheus.NewDesc(
+			"scaleway_billing_invoice_total_untaxed",
+			"The total amount of the invoice, taxes excluded",
+			append(append([]string{}, labelsInvoice...), "currency_code"), nil,
+		),
+		InvoiceIssuedAt: prometheus.NewDesc(
+			"scaleway_billing_invoice_issued_timestamp_seconds",
+			"Timestamp of the issue of the invoice",
+			labelsInvoice, nil,
+		),
+		InvoiceDueAt: prometheus.NewDesc(
+			"scaleway_billing_invoice_due_timestamp_seconds",
+			"Timestamp of the due date of the invoice",
+			labelsInvoice, nil,
+		),
+		DiscountRemaining: prometheus.NewDesc(
+			"scaleway_billing_discount_remaining",
+			"The remaining value of the active discount, a percentage for the rate discounts",
+			labelsDiscount, nil,
+		),
+		DiscountExpiresAt: prometheus.NewDesc(
+			"scaleway_billing_discount_expires_at_timestamp_seconds",
+			"Timestamp of the expiration of the active discount, only for the discounts with an expiration date",
+			labelsDiscount, nil,
 		),
 	}
 }
@@ -58,33 +166,36 @@ func NewBillingCollector(logger log.Logger, errors *prometheus.CounterVec, clien
 // collected by this Collector.
 func (c *BillingCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.Consumptions
-}
-
-type ConsumptionValue struct {
-	CurrencyCode string `json:"currency_code"`
-	Units        int32  `json:"units"`
-	Nanos        int32  `json:"nanos"`
-}
-
-type Consumption struct {
-	Description   string           `json:"description"`
-	ProjectID     string           `json:"project_id"`
-	Category      string           `json:"category"`
-	OperationPath string           `json:"operation_path"`
-	Value         ConsumptionValue `json:"value"`
-}
-
-type BillingResponse struct {
-	Consumptions []*Consumption `json:"consumptions"`
-	UpdatedAt    time.Time      `json:"updated_at"`
+	ch <- c.ConsumptionsNormalized
+	ch <- c.ConsumptionQuantity
+	ch <- c.CategoryConsumption
+	ch <- c.ProjectConsumption
+	ch <- c.Forecast
+	ch <- c.BudgetLimit
+	ch <- c.BudgetUsageRatio
+	ch <- c.Update
+	ch <- c.InvoiceTotalTaxed
+	ch <- c.InvoiceTotalUntaxed
+	ch <- c.InvoiceIssuedAt
+	ch <- c.InvoiceDueAt
+	ch <- c.DiscountRemaining
+	ch <- c.DiscountExpiresAt
 }
 
 // Collect is called by the Prometheus registry when collecting metrics.
 func (c *BillingCollector) Collect(ch chan<- prometheus.Metric) {
-	_, cancel := context.WithTimeout(context.Background(), c.timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
 	defer cancel()
 
-	response, err := c.accountClient.ListProjects(&account.ListProjectsRequest{OrganizationID: c.organizationID}, scw.WithAllPages())
+	for _, organizationID := range c.organizationIDs {
+		c.CollectConsumptions(ctx, ch, organizationID)
+		c.CollectInvoices(ctx, ch, organizationID)
+		c.CollectDiscounts(ctx, ch, organizationID)
+	}
+}
+
+func (c *BillingCollector) CollectConsumptions(ctx context.Context, ch chan<- prometheus.Metric, organizationID string) {
+	response, err := c.accountClient.ListProjects(&account.ListProjectsRequest{OrganizationID: organizationID}, scw.WithAllPages(), scw.WithContext(ctx))
 
 	if err != nil {
 		c.errors.WithLabelValues("billing").Add(1)
@@ -106,46 +217,318 @@ func (c *BillingCollector) Collect(ch chan<- prometheus.Metric) {
 		projects[project.ID] = project.Name
 	}
 
-	query := url.Values{}
+	now := time.Now()
+
+	currentPeriod := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	for period, start := range map[string]time.Time{
+		"current":  currentPeriod,
+		"previous": currentPeriod.AddDate(0, -1, 0),
+	} {
+		billingResponse, err := c.FetchConsumptions(ctx, organizationID, start)
+
+		if err != nil {
+			c.errors.WithLabelValues("billing").Add(1)
+			_ = level.Warn(c.logger).Log(
+				"msg", "Could not fetch the billing data, perhaps you are missing the 'BillingReadOnly' permission'",
+				"period", period,
+				"err", err,
+			)
+
+			continue
+		}
+
+		totals := make(map[string]float64)
+
+		// keyed by category or project, then by currency
+		categories := make(map[string]map[string]float64)
+		projectTotals := make(map[string]map[string]float64)
+
+		budgets := make(map[*Budget]float64)
+		unconverted := make(map[*Budget]bool)
+
+		for _, consumption := range billingResponse.Consumptions {
+			labelsConsumption := []string{
+				organizationID,
+				consumption.ProjectID,
+				projects[consumption.ProjectID],
+				consumption.CategoryName,
+				consumption.ProductName,
+				consumption.ResourceName,
+				consumption.Sku,
+				period,
+				start.Format("2006-01"),
+			}
+
+			if consumption.Value == nil {
+				continue
+			}
+
+			value := consumption.Value.ToFloat()
+
+			totals[consumption.Value.CurrencyCode] += value
+
+			if categories[consumption.CategoryName] == nil {
+				categories[consumption.CategoryName] = make(map[string]float64)
+			}
+
+			categories[consumption.CategoryName][consumption.Value.CurrencyCode] += value
+
+			if projectTotals[consumption.ProjectID] == nil {
+				projectTotals[consumption.ProjectID] = make(map[string]float64)
+			}
+
+			projectTotals[consumption.ProjectID][consumption.Value.CurrencyCode] += value
+
+			if period == "current" {
+				c.ConsumeBudgets(budgets, unconverted, organizationID, consumption, value)
+			}
+
+			ch <- prometheus.MustNewConstMetric(
+				c.Consumptions,
+				prometheus.GaugeValue,
+				value,
+				append(append([]string{}, labelsConsumption...), consumption.Value.CurrencyCode)...,
+			)
+
+			if c.options.Currency != "" {
+				if normalized, ok := c.options.Rates.Convert(value, consumption.Value.CurrencyCode, c.options.Currency); ok {
+					ch <- prometheus.MustNewConstMetric(
+						c.ConsumptionsNormalized,
+						prometheus.GaugeValue,
+						normalized,
+						append(append([]string{}, labelsConsumption...), c.options.Currency)...,
+					)
+				} else {
+					_ = level.Debug(c.logger).Log("msg", "no rate to convert the consumption", "from", consumption.Value.CurrencyCode, "to", c.options.Currency)
+				}
+			}
+
+			if consumption.BilledQuantity == "" {
+				continue
+			}
 
-	query.Set("organization_id", c.organizationID)
+			quantity, err := strconv.ParseFloat(consumption.BilledQuantity, 64)
 
-	var billingResponse BillingResponse
+			if err != nil {
+				continue
+			}
 
-	err = c.client.Do(&scw.ScalewayRequest{
-		Method:  "GET",
-		Path:    "/billing/v2alpha1/consumption",
-		Query:   query,
-		Headers: http.Header{},
-	}, &billingResponse)
+			ch <- prometheus.MustNewConstMetric(
+				c.ConsumptionQuantity,
+				prometheus.GaugeValue,
+				quantity,
+				append(append([]string{}, labelsConsumption...), consumption.Unit)...,
+			)
+		}
+
+		for category, values := range categories {
+			for currency, value := range values {
+				ch <- prometheus.MustNewConstMetric(
+					c.CategoryConsumption,
+					prometheus.GaugeValue,
+					value,
+					organizationID, category, period, start.Format("2006-01"), currency,
+				)
+			}
+		}
+
+		for projectID, values := range projectTotals {
+			for currency, value := range values {
+				ch <- prometheus.MustNewConstMetric(
+					c.ProjectConsumption,
+					prometheus.GaugeValue,
+					value,
+					organizationID, projectID, projects[projectID], period, start.Format("2006-01"), currency,
+				)
+			}
+		}
+
+		if period != "current" {
+			continue
+		}
+
+		for _, budget := range c.options.Budgets {
+			if budget.OrganizationID != "" && budget.OrganizationID != organizationID {
+				continue
+			}
+
+			labelsBudget := []string{organizationID, budget.Name, budget.ProjectID, budget.Category, budget.Currency}
+
+			ch <- prometheus.MustNewConstMetric(c.BudgetLimit, prometheus.GaugeValue, budget.Limit, labelsBudget...)
+
+			// a usage missing some consumptions would understate the ratio
+			if unconverted[budget] {
+				continue
+			}
+
+			ch <- prometheus.MustNewConstMetric(c.BudgetUsageRatio, prometheus.GaugeValue, budgets[budget]/budget.Limit, labelsBudget...)
+		}
+
+		// the consumptions are only as recent as their last update, so is the elapsed fraction of the period
+		lastUpdate := now
+
+		if billingResponse.UpdatedAt != nil {
+			lastUpdate = *billingResponse.UpdatedAt
+
+			ch <- prometheus.MustNewConstMetric(
+				c.Update,
+				prometheus.GaugeValue,
+				float64(billingResponse.UpdatedAt.Unix()),
+				organizationID,
+			)
+		}
+
+		elapsed := lastUpdate.Sub(start).Seconds() / start.AddDate(0, 1, 0).Sub(start).Seconds()
+
+		if elapsed <= 0 {
+			continue
+		}
+
+		for currency, total := range totals {
+			ch <- prometheus.MustNewConstMetric(c.Forecast, prometheus.GaugeValue, total/math.Min(elapsed, 1), organizationID, currency)
+		}
+	}
+}
+
+// ConsumeBudgets adds the value of the consumption to the usage of the budgets it matches, converted into their currency,
+// the budgets whose currency the consumption can't be converted into are flagged as unconverted.
+func (c *BillingCollector) ConsumeBudgets(usages map[*Budget]float64, unconverted map[*Budget]bool, organizationID string, consumption *billing.ListConsumptionsResponseConsumption, value float64) {
+	for _, budget := range c.options.Budgets {
+		if !budget.Matches(organizationID, consumption) {
+			continue
+		}
+
+		if strings.EqualFold(budget.Currency, consumption.Value.CurrencyCode) {
+			usages[budget] += value
+
+			continue
+		}
+
+		if c.options.Rates != nil {
+			if converted, ok := c.options.Rates.Convert(value, consumption.Value.CurrencyCode, budget.Currency); ok {
+				usages[budget] += converted
+
+				continue
+			}
+		}
+
+		if !unconverted[budget] {
+			c.errors.WithLabelValues("billing").Add(1)
+			_ = level.Warn(c.logger).Log(
+				"msg", "no rate to convert the consumption into the budget currency, skipping the budget usage",
+				"budget", budget.Name,
+				"currency", consumption.Value.CurrencyCode,
+				"budgetCurrency", budget.Currency,
+			)
+		}
+
+		unconverted[budget] = true
+	}
+}
+
+// FetchConsumptions returns the consumptions of the organization for the billing period starting at the given date.
+func (c *BillingCollector) FetchConsumptions(ctx context.Context, organizationID string, periodStart time.Time) (*billing.ListConsumptionsResponse, error) {
+	return c.billingClient.ListConsumptions(&billing.ListConsumptionsRequest{
+		OrganizationID: &organizationID,
+		BillingPeriod:  scw.StringPtr(periodStart.Format("2006-01")),
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+}
+
+func (c *BillingCollector) CollectInvoices(ctx context.Context, ch chan<- prometheus.Metric, organizationID string) {
+	// only the invoices of the last year are exposed to keep the cardinality bounded
+	response, err := c.billingClient.ListInvoices(&billing.ListInvoicesRequest{
+		OrganizationID:          &organizationID,
+		BillingPeriodStartAfter: scw.TimePtr(time.Now().AddDate(-1, 0, 0)),
+	}, scw.WithAllPages(), scw.WithContext(ctx))
 
 	if err != nil {
 		c.errors.WithLabelValues("billing").Add(1)
 		_ = level.Warn(c.logger).Log(
-			"msg", "Could not fetch the billing data, perhaps you are missing the 'BillingReadOnly' permission'",
+			"msg", "Could not fetch the invoices, perhaps you are missing the 'BillingReadOnly' permission'",
 			"err", err,
 		)
 
 		return
 	}
 
-	for _, consumption := range billingResponse.Consumptions {
-		ch <- prometheus.MustNewConstMetric(
-			c.Consumptions,
-			prometheus.GaugeValue,
-			float64(consumption.Value.Units)+float64(consumption.Value.Nanos)/1e9,
-			consumption.ProjectID,
-			projects[consumption.ProjectID],
-			consumption.Category,
-			consumption.OperationPath,
-			consumption.Description,
-			consumption.Value.CurrencyCode,
+	for _, invoice := range response.Invoices {
+		var period string
+
+		if invoice.BillingPeriod != nil {
+			period = invoice.BillingPeriod.Format("2006-01")
+		}
+
+		labelsInvoice := []string{organizationID, invoice.ID, fmt.Sprint(invoice.Number), invoice.Type.String(), invoice.State, period}
+
+		if invoice.TotalTaxed != nil {
+			ch <- prometheus.MustNewConstMetric(
+				c.InvoiceTotalTaxed,
+				prometheus.GaugeValue,
+				invoice.TotalTaxed.ToFloat(),
+				append(append([]string{}, labelsInvoice...), invoice.TotalTaxed.CurrencyCode)...,
+			)
+		}
+
+		if invoice.TotalUntaxed != nil {
+			ch <- prometheus.MustNewConstMetric(
+				c.InvoiceTotalUntaxed,
+				prometheus.GaugeValue,
+				invoice.TotalUntaxed.ToFloat(),
+				append(append([]string{}, labelsInvoice...), invoice.TotalUntaxed.CurrencyCode)...,
+			)
+		}
+
+		if invoice.IssuedDate != nil {
+			ch <- prometheus.MustNewConstMetric(c.InvoiceIssuedAt, prometheus.GaugeValue, float64(invoice.IssuedDate.Unix()), labelsInvoice...)
+		}
+
+		if invoice.DueDate != nil {
+			ch <- prometheus.MustNewConstMetric(c.InvoiceDueAt, prometheus.GaugeValue, float64(invoice.DueDate.Unix()), labelsInvoice...)
+		}
+	}
+}
+
+func (c *BillingCollector) CollectDiscounts(ctx context.Context, ch chan<- prometheus.Metric, organizationID string) {
+	response, err := c.billingClient.ListDiscounts(&billing.ListDiscountsRequest{OrganizationID: &organizationID}, scw.WithAllPages(), scw.WithContext(ctx))
+
+	if err != nil {
+		c.errors.WithLabelValues("billing").Add(1)
+		_ = level.Warn(c.logger).Log(
+			"msg", "Could not fetch the discounts, perhaps you are missing the 'BillingReadOnly' permission'",
+			"err", err,
 		)
+
+		return
 	}
 
-	ch <- prometheus.MustNewConstMetric(
-		c.Update,
-		prometheus.GaugeValue,
-		float64(billingResponse.UpdatedAt.Unix()),
-	)
+	now := time.Now()
+
+	for _, discount := range response.Discounts {
+		if (discount.StartDate != nil && discount.StartDate.After(now)) || (discount.StopDate != nil && discount.StopDate.Before(now)) {
+			continue
+		}
+
+		scopes := make([]string, 0, len(discount.Filters))
+
+		for _, filter := range discount.Filters {
+			scopes = append(scopes, filter.Type.String()+":"+filter.Value)
+		}
+
+		sort.Strings(scopes)
+
+		scope := "organization"
+
+		if len(scopes) > 0 {
+			scope = strings.Join(scopes, ",")
+		}
+
+		labelsDiscount := []string{organizationID, discount.ID, discount.Description, discount.Mode.String(), scope}
+
+		ch <- prometheus.MustNewConstMetric(c.DiscountRemaining, prometheus.GaugeValue, discount.ValueRemaining, labelsDiscount...)
+
+		if discount.StopDate != nil {
+			ch <- prometheus.MustNewConstMetric(c.DiscountExpiresAt, prometheus.GaugeValue, float64(discount.StopDate.Unix()), labelsDiscount...)
+		}
+	}
 }
@@ -0,0 +1,385 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	block "github.com/scaleway/scaleway-sdk-go/api/block/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// BlockCollector collects metrics about all block storage volumes, both from the Block Storage (SBS) and the Instance APIs.
+type BlockCollector struct {
+	logger         log.Logger
+	errors         *prometheus.CounterVec
+	instanceClient *instance.API
+	blockClient    *block.API
+	timeout        time.Duration
+	zones          []scw.Zone
+	projects       ProjectFilter
+	tags           TagFilter
+	tagLabels      TagLabels
+
+	VolumeUp       *prometheus.Desc
+	VolumeSize     *prometheus.Desc
+	VolumeAttached *prometheus.Desc
+	SnapshotUp     *prometheus.Desc
+	SnapshotSize   *prometheus.Desc
+	SnapshotAge    *prometheus.Desc
+}
+
+// NewBlockCollector returns a new BlockCollector.
+func NewBlockCollector(logger log.Logger, errors *prometheus.CounterVec, client *scw.Client, timeout time.Duration, zones []scw.Zone, projects ProjectFilter, tags TagFilter, tagLabels TagLabels) *BlockCollector {
+	errors.WithLabelValues("block").Add(0)
+
+	_ = level.Info(logger).Log("msg", "Block collector enabled")
+
+	labels := []string{"id", "name", "zone", "project_id", "type"}
+
+	labelsSnapshot := []string{"id", "name", "zone", "project_id", "type", "volume_id", "volume_name"}
+
+	return &BlockCollector{
+		logger:         logger,
+		errors:         errors,
+		instanceClient: instance.NewAPI(client),
+		blockClient:    block.NewAPI(client),
+		timeout:        timeout,
+		zones:          zones,
+		projects:       projects,
+		tags:           tags,
+		tagLabels:      tagLabels,
+
+		VolumeUp: prometheus.NewDesc(
+			"scaleway_block_volume_up",
+			"If 1 the volume is available or in use, 0.5 when being created, resized or snapshotted, 0 otherwise",
+			append(append([]string{}, labels...), append([]string{"state"}, tagLabels.Names()...)...), nil,
+		),
+		VolumeSize: prometheus.NewDesc(
+			"scaleway_block_volume_size_bytes",
+			"The size of the volume",
+			labels, nil,
+		),
+		VolumeAttached: prometheus.NewDesc(
+			"scaleway_block_volume_attached",
+			"If 1 the volume is attached to a resource, 0 otherwise",
+			labels, nil,
+		),
+		SnapshotUp: prometheus.NewDesc(
+			"scaleway_block_snapshot_up",
+			"If 1 the snapshot is available, 0.5 when being created, imported or exported, 0 otherwise",
+			append(append([]string{}, labelsSnapshot...), append([]string{"state"}, tagLabels.Names()...)...), nil,
+		),
+		SnapshotSize: prometheus.NewDesc(
+			"scaleway_block_snapshot_size_bytes",
+			"The size of the snapshot",
+			labelsSnapshot, nil,
+		),
+		SnapshotAge: prometheus.NewDesc(
+			"scaleway_block_snapshot_age_seconds",
+			"The number of seconds since the snapshot was created",
+			labelsSnapshot, nil,
+		),
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+// collected by this Collector.
+func (c *BlockCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.VolumeUp
+	ch <- c.VolumeSize
+	ch <- c.VolumeAttached
+	ch <- c.SnapshotUp
+	ch <- c.SnapshotSize
+	ch <- c.SnapshotAge
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *BlockCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	for _, zone := range c.zones {
+		c.CollectInstanceVolumes(ctx, ch, zone)
+		c.CollectSBSVolumes(ctx, ch, zone)
+		c.CollectInstanceSnapshots(ctx, ch, zone)
+		c.CollectSBSSnapshots(ctx, ch, zone)
+	}
+}
+
+func (c *BlockCollector) CollectInstanceVolumes(ctx context.Context, ch chan<- prometheus.Metric, zone scw.Zone) {
+	response, err := c.instanceClient.ListVolumes(&instance.ListVolumesRequest{Zone: zone}, scw.WithAllPages(), scw.WithContext(ctx))
+
+	if err != nil {
+		var responseError *scw.ResponseError
+
+		switch {
+		case errors.As(err, &responseError) && responseError.StatusCode == http.StatusNotImplemented:
+			_ = level.Debug(c.logger).Log("msg", "Instance volumes are not supported in this zone", "zone", zone)
+		default:
+			c.errors.WithLabelValues("block").Add(1)
+			_ = level.Warn(c.logger).Log("msg", "can't fetch the list of instance volumes", "err", err, "zone", zone)
+		}
+
+		return
+	}
+
+	_ = level.Debug(c.logger).Log("msg", fmt.Sprintf("found %d instance volumes", len(response.Volumes)), "zone", zone)
+
+	for _, volume := range response.Volumes {
+		if !c.projects.Match(volume.Project) || !c.tags.Match(volume.Tags) {
+			continue
+		}
+
+		labels := []string{
+			volume.ID,
+			volume.Name,
+			volume.Zone.String(),
+			volume.Project,
+			volume.VolumeType.String(),
+		}
+
+		var active float64
+
+		switch volume.State {
+		case instance.VolumeStateAvailable:
+			active = 1.0
+		case instance.VolumeStateSnapshotting:
+			active = 0.5
+		case instance.VolumeStateFetching:
+			active = 0.5
+		case instance.VolumeStateResizing:
+			active = 0.5
+		case instance.VolumeStateSaving:
+			active = 0.5
+		case instance.VolumeStateHotsyncing:
+			active = 0.5
+		case instance.VolumeStateError:
+			active = 0.0
+		default:
+			active = 0.0
+		}
+
+		var attached float64
+
+		if volume.Server != nil {
+			attached = 1.0
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.VolumeUp, prometheus.GaugeValue, active, append(append([]string{}, labels...), append([]string{volume.State.String()}, c.tagLabels.Values(volume.Tags)...)...)...)
+		ch <- prometheus.MustNewConstMetric(c.VolumeSize, prometheus.GaugeValue, float64(volume.Size), labels...)
+		ch <- prometheus.MustNewConstMetric(c.VolumeAttached, prometheus.GaugeValue, attached, labels...)
+	}
+}
+
+func (c *BlockCollector) CollectSBSVolumes(ctx context.Context, ch chan<- prometheus.Metric, zone scw.Zone) {
+	response, err := c.blockClient.ListVolumes(&block.ListVolumesRequest{Zone: zone}, scw.WithAllPages(), scw.WithContext(ctx))
+
+	if err != nil {
+		var responseError *scw.ResponseError
+
+		switch {
+		case errors.As(err, &responseError) && (responseError.StatusCode == http.StatusNotImplemented || responseError.StatusCode == http.StatusNotFound):
+			_ = level.Debug(c.logger).Log("msg", "Block Storage is not supported in this zone", "zone", zone)
+		default:
+			c.errors.WithLabelValues("block").Add(1)
+			_ = level.Warn(c.logger).Log("msg", "can't fetch the list of block storage volumes", "err", err, "zone", zone)
+		}
+
+		return
+	}
+
+	_ = level.Debug(c.logger).Log("msg", fmt.Sprintf("found %d block storage volumes", len(response.Volumes)), "zone", zone)
+
+	for _, volume := range response.Volumes {
+		if !c.projects.Match(volume.ProjectID) || !c.tags.Match(volume.Tags) {
+			continue
+		}
+
+		labels := []string{
+			volume.ID,
+			volume.Name,
+			volume.Zone.String(),
+			volume.ProjectID,
+			volume.Type,
+		}
+
+		var active float64
+
+		switch volume.Status {
+		case block.VolumeStatusAvailable:
+			active = 1.0
+		case block.VolumeStatusInUse:
+			active = 1.0
+		case block.VolumeStatusCreating:
+			active = 0.5
+		case block.VolumeStatusResizing:
+			active = 0.5
+		case block.VolumeStatusSnapshotting:
+			active = 0.5
+		case block.VolumeStatusUpdating:
+			active = 0.5
+		case block.VolumeStatusDeleting:
+			active = 0.5
+		case block.VolumeStatusError:
+			active = 0.0
+		default:
+			active = 0.0
+		}
+
+		var attached float64
+
+		if len(volume.References) > 0 {
+			attached = 1.0
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.VolumeUp, prometheus.GaugeValue, active, append(append([]string{}, labels...), append([]string{volume.Status.String()}, c.tagLabels.Values(volume.Tags)...)...)...)
+		ch <- prometheus.MustNewConstMetric(c.VolumeSize, prometheus.GaugeValue, float64(volume.Size), labels...)
+		ch <- prometheus.MustNewConstMetric(c.VolumeAttached, prometheus.GaugeValue, attached, labels...)
+	}
+}
+
+func (c *BlockCollector) CollectInstanceSnapshots(ctx context.Context, ch chan<- prometheus.Metric, zone scw.Zone) {
+	response, err := c.instanceClient.ListSnapshots(&instance.ListSnapshotsRequest{Zone: zone}, scw.WithAllPages(), scw.WithContext(ctx))
+
+	if err != nil {
+		var responseError *scw.ResponseError
+
+		switch {
+		case errors.As(err, &responseError) && responseError.StatusCode == http.StatusNotImplemented:
+			_ = level.Debug(c.logger).Log("msg", "Instance snapshots are not supported in this zone", "zone", zone)
+		default:
+			c.errors.WithLabelValues("block").Add(1)
+			_ = level.Warn(c.logger).Log("msg", "can't fetch the list of instance snapshots", "err", err, "zone", zone)
+		}
+
+		return
+	}
+
+	_ = level.Debug(c.logger).Log("msg", fmt.Sprintf("found %d instance snapshots", len(response.Snapshots)), "zone", zone)
+
+	for _, snapshot := range response.Snapshots {
+		if !c.projects.Match(snapshot.Project) || !c.tags.Match(snapshot.Tags) {
+			continue
+		}
+
+		var volumeID, volumeName string
+
+		if snapshot.BaseVolume != nil {
+			volumeID = snapshot.BaseVolume.ID
+			volumeName = snapshot.BaseVolume.Name
+		}
+
+		labels := []string{
+			snapshot.ID,
+			snapshot.Name,
+			snapshot.Zone.String(),
+			snapshot.Project,
+			snapshot.VolumeType.String(),
+			volumeID,
+			volumeName,
+		}
+
+		var active float64
+
+		switch snapshot.State {
+		case instance.SnapshotStateAvailable:
+			active = 1.0
+		case instance.SnapshotStateSnapshotting:
+			active = 0.5
+		case instance.SnapshotStateImporting:
+			active = 0.5
+		case instance.SnapshotStateExporting:
+			active = 0.5
+		case instance.SnapshotStateError:
+			active = 0.0
+		case instance.SnapshotStateInvalidData:
+			active = 0.0
+		default:
+			active = 0.0
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.SnapshotUp, prometheus.GaugeValue, active, append(append([]string{}, labels...), append([]string{snapshot.State.String()}, c.tagLabels.Values(snapshot.Tags)...)...)...)
+		ch <- prometheus.MustNewConstMetric(c.SnapshotSize, prometheus.GaugeValue, float64(snapshot.Size), labels...)
+
+		if snapshot.CreationDate != nil {
+			ch <- prometheus.MustNewConstMetric(c.SnapshotAge, prometheus.GaugeValue, time.Since(*snapshot.CreationDate).Seconds(), labels...)
+		}
+	}
+}
+
+func (c *BlockCollector) CollectSBSSnapshots(ctx context.Context, ch chan<- prometheus.Metric, zone scw.Zone) {
+	response, err := c.blockClient.ListSnapshots(&block.ListSnapshotsRequest{Zone: zone}, scw.WithAllPages(), scw.WithContext(ctx))
+
+	if err != nil {
+		var responseError *scw.ResponseError
+
+		switch {
+		case errors.As(err, &responseError) && (responseError.StatusCode == http.StatusNotImplemented || responseError.StatusCode == http.StatusNotFound):
+			_ = level.Debug(c.logger).Log("msg", "Block Storage is not supported in this zone", "zone", zone)
+		default:
+			c.errors.WithLabelValues("block").Add(1)
+			_ = level.Warn(c.logger).Log("msg", "can't fetch the list of block storage snapshots", "err", err, "zone", zone)
+		}
+
+		return
+	}
+
+	_ = level.Debug(c.logger).Log("msg", fmt.Sprintf("found %d block storage snapshots", len(response.Snapshots)), "zone", zone)
+
+	for _, snapshot := range response.Snapshots {
+		if !c.projects.Match(snapshot.ProjectID) || !c.tags.Match(snapshot.Tags) {
+			continue
+		}
+
+		var volumeID, volumeName, volumeType string
+
+		if snapshot.ParentVolume != nil {
+			volumeID = snapshot.ParentVolume.ID
+			volumeName = snapshot.ParentVolume.Name
+			volumeType = snapshot.ParentVolume.Type
+		}
+
+		labels := []string{
+			snapshot.ID,
+			snapshot.Name,
+			snapshot.Zone.String(),
+			snapshot.ProjectID,
+			volumeType,
+			volumeID,
+			volumeName,
+		}
+
+		var active float64
+
+		switch snapshot.Status {
+		case block.SnapshotStatusAvailable:
+			active = 1.0
+		case block.SnapshotStatusInUse:
+			active = 1.0
+		case block.SnapshotStatusCreating:
+			active = 0.5
+		case block.SnapshotStatusExporting:
+			active = 0.5
+		case block.SnapshotStatusDeleting:
+			active = 0.5
+		case block.SnapshotStatusError:
+			active = 0.0
+		default:
+			active = 0.0
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.SnapshotUp, prometheus.GaugeValue, active, append(append([]string{}, labels...), append([]string{snapshot.Status.String()}, c.tagLabels.Values(snapshot.Tags)...)...)...)
+		ch <- prometheus.MustNewConstMetric(c.SnapshotSize, prometheus.GaugeValue, float64(snapshot.Size), labels...)
+
+		if snapshot.CreatedAt != nil {
+			ch <- prometheus.MustNewConstMetric(c.SnapshotAge, prometheus.GaugeValue, time.Since(*snapshot.CreatedAt).Seconds(), labels...)
+		}
+	}
+}
@@ -2,7 +2,10 @@ package collector
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
 	"sort"
@@ -11,25 +14,93 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	"github.com/prometheus/client_golang/prometheus"
+	account "github.com/scaleway/scaleway-sdk-go/api/account/v2"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
+// BucketOptions are the optional settings of the BucketCollector.
+type BucketOptions struct {
+	// TagLabels are the tags of the buckets exposed as labels of the scaleway_s3_bucket_info metric.
+	TagLabels []string
+	// MappedTagLabels are the tags of the buckets exposed as labels of the scaleway_s3_bucket_info metric under a chosen name.
+	MappedTagLabels TagLabels
+	// Endpoint of the S3 API, the {region} placeholder is replaced by the region of the buckets.
+	Endpoint string
+	// OrganizationIDs enables the discovery of the buckets of every project of the organizations when set.
+	OrganizationIDs []string
+	// Projects restricts the collected buckets to some projects.
+	Projects ProjectFilter
+	// HTTPClient sends the requests of the S3 clients, the default client of the AWS SDK is used when nil.
+	HTTPClient *http.Client
+	// Timestamps dates the object count and size metrics with the time Scaleway measured them instead of the scrape time.
+	Timestamps bool
+	// Probes are the optional S3 requests sent for each bucket on every scrape.
+	Probes BucketProbes
+}
+
+// BucketProbes are the optional S3 requests sent for each bucket, by name.
+type BucketProbes map[string]bool
+
+// bucketProbeNames are the known bucket probes.
+var bucketProbeNames = []string{"versioning", "object_lock", "multipart", "replication", "acl", "policy", "website"} //nolint:gochecknoglobals // constant list
+
+// ParseBucketProbes checks the names of the enabled bucket probes.
+func ParseBucketProbes(names []string) (BucketProbes, error) {
+	probes := BucketProbes{}
+
+	for _, name := range names {
+		known := false
+
+		for _, probe := range bucketProbeNames {
+			known = known || probe == name
+		}
+
+		if !known {
+			return nil, fmt.Errorf("unknown bucket probe %s, expected one of %s", name, strings.Join(bucketProbeNames, ", "))
+		}
+
+		probes[name] = true
+	}
+
+	return probes, nil
+}
+
 // BucketCollector collects metrics about all buckets.
 type BucketCollector struct {
-	logger    log.Logger
-	errors    *prometheus.CounterVec
-	endpoints []Endpoint
-	timeout   time.Duration
+	logger        log.Logger
+	errors        *prometheus.CounterVec
+	endpoints     []Endpoint
+	timeout       time.Duration
+	options       BucketOptions
+	cache         *ListingCache
+	accountClient *account.API
 
 	ObjectCount  *prometheus.Desc
+	ClassCount   *prometheus.Desc
 	Bandwidth    *prometheus.Desc
+	BandwidthIn  *prometheus.Desc
 	StorageUsage *prometheus.Desc
+	Info         *prometheus.Desc
+	InfoAge      *prometheus.Desc
+	BucketCount  *prometheus.Desc
+	Versioning   *prometheus.Desc
+	ObjectLock   *prometheus.Desc
+	Multiparts   *prometheus.Desc
+	Replication  *prometheus.Desc
+	Exposure     *prometheus.Desc
+	MultipartAge *prometheus.Desc
+	QuotaBuckets *prometheus.Desc
+	QuotaObjects *prometheus.Desc
+	QuotaSize    *prometheus.Desc
+	UsageObjects *prometheus.Desc
+	UsageSize    *prometheus.Desc
 }
 
 type Endpoint struct {
@@ -39,7 +110,7 @@ type Endpoint struct {
 }
 
 // NewBucketCollector returns a new BucketCollector.
-func NewBucketCollector(logger log.Logger, errors *prometheus.CounterVec, client *scw.Client, timeout time.Duration, regions []scw.Region) *BucketCollector {
+func NewBucketCollector(logger log.Logger, errors *prometheus.CounterVec, client *scw.Client, timeout time.Duration, regions []scw.Region, cache *ListingCache, options BucketOptions) *BucketCollector {
 	errors.WithLabelValues("bucket").Add(0)
 
 	_ = level.Info(logger).Log("msg", "Bucket collector enabled")
@@ -48,6 +119,12 @@ func NewBucketCollector(logger log.Logger, errors *prometheus.CounterVec, client
 
 	secretKey, _ := client.GetSecretKey()
 
+	s3Endpoint := options.Endpoint
+
+	if s3Endpoint == "" {
+		s3Endpoint = "https://s3.{region}.scw.cloud"
+	}
+
 	endpoints := make([]Endpoint, len(regions))
 
 	for i, region := range regions {
@@ -62,8 +139,9 @@ func NewBucketCollector(logger log.Logger, errors *prometheus.CounterVec, client
 		}
 
 		s3Client := s3.New(newSession, &aws.Config{
-			Endpoint:         aws.String("https://s3." + fmt.Sprint(region) + ".scw.cloud"),
+			Endpoint:         aws.String(strings.ReplaceAll(s3Endpoint, "{region}", fmt.Sprint(region))),
 			S3ForcePathStyle: aws.Bool(true),
+			HTTPClient:       options.HTTPClient,
 		})
 
 		endpoints[i] = Endpoint{
@@ -72,36 +150,162 @@ func NewBucketCollector(logger log.Logger, errors *prometheus.CounterVec, client
 			region:   region,
 		}
 	}
+
+	labelsInfo := []string{"name", "region", "public", "project_id"}
+
+	for _, tag := range options.TagLabels {
+		labelsInfo = append(labelsInfo, TagLabelName(tag))
+	}
+
+	labelsInfo = append(labelsInfo, options.MappedTagLabels.Names()...)
+
 	return &BucketCollector{
 		logger:    logger,
 		errors:    errors,
 		endpoints: endpoints,
 		timeout:   timeout,
+		options:   options,
+		cache:     cache,
+
+		accountClient: account.NewAPI(client),
 
 		ObjectCount: prometheus.NewDesc(
 			"scaleway_s3_object_total",
 			"Number of objects, excluding parts",
-			[]string{"name", "region", "public"}, nil,
+			[]string{"name", "region", "public", "project_id"}, nil,
 		),
 		Bandwidth: prometheus.NewDesc(
 			"scaleway_s3_bandwidth_bytes",
 			"Bucket's Bandwidth usage",
-			[]string{"name", "region", "public"}, nil,
+			[]string{"name", "region", "public", "project_id"}, nil,
+		),
+		ClassCount: prometheus.NewDesc(
+			"scaleway_s3_storage_class_object_total",
+			"Number of objects per storage class, excluding parts",
+			[]string{"name", "region", "public", "project_id", "storage_class"}, nil,
+		),
+		BandwidthIn: prometheus.NewDesc(
+			"scaleway_s3_bandwidth_received_bytes",
+			"Bucket's ingress Bandwidth usage",
+			[]string{"name", "region", "public", "project_id"}, nil,
 		),
 		StorageUsage: prometheus.NewDesc(
 			"scaleway_s3_storage_usage_bytes",
 			"Bucket's Storage usage",
-			[]string{"name", "region", "public", "storage_class"}, nil,
+			[]string{"name", "region", "public", "project_id", "storage_class"}, nil,
+		),
+		Info: prometheus.NewDesc(
+			"scaleway_s3_bucket_info",
+			"A metric with a constant '1' value labeled by the bucket information and its configured tags",
+			labelsInfo, nil,
+		),
+		Versioning: prometheus.NewDesc(
+			"scaleway_s3_bucket_versioning_enabled",
+			"If 1 the versioning of the bucket is enabled, 0 otherwise",
+			[]string{"name", "region", "public", "project_id", "status"}, nil,
+		),
+		ObjectLock: prometheus.NewDesc(
+			"scaleway_s3_bucket_object_lock_enabled",
+			"If 1 the object lock of the bucket is enabled, 0 otherwise",
+			[]string{"name", "region", "public", "project_id", "mode"}, nil,
+		),
+		Multiparts: prometheus.NewDesc(
+			"scaleway_s3_multipart_uploads",
+			"Number of in-progress multipart uploads of the bucket",
+			[]string{"name", "region", "public", "project_id"}, nil,
+		),
+		MultipartAge: prometheus.NewDesc(
+			"scaleway_s3_multipart_upload_oldest_age_seconds",
+			"The number of seconds since the oldest in-progress multipart upload of the bucket was initiated",
+			[]string{"name", "region", "public", "project_id"}, nil,
+		),
+		Replication: prometheus.NewDesc(
+			"scaleway_s3_bucket_replication_enabled",
+			"If 1 the bucket is replicated to the destination bucket, 0 when no replication is configured",
+			[]string{"name", "region", "public", "project_id", "destination_bucket", "destination_region"}, nil,
+		),
+		Exposure: prometheus.NewDesc(
+			"scaleway_s3_bucket_public_exposure",
+			"If 1 the bucket is publicly exposed through the vector (acl, policy or website), 0 otherwise",
+			[]string{"name", "region", "public", "project_id", "vector"}, nil,
+		),
+		InfoAge: prometheus.NewDesc(
+			"scaleway_s3_bucket_info_age_seconds",
+			"Age of the object count and size reported for the bucket, the API refreshes them with a delay",
+			[]string{"name", "region", "public", "project_id"}, nil,
+		),
+		BucketCount: prometheus.NewDesc(
+			"scaleway_s3_buckets_total",
+			"Number of buckets in the region",
+			[]string{"region"}, nil,
+		),
+		QuotaBuckets: prometheus.NewDesc(
+			"scaleway_s3_quota_buckets",
+			"The maximum number of buckets of the project in the region",
+			[]string{"region", "project_id"}, nil,
+		),
+		QuotaObjects: prometheus.NewDesc(
+			"scaleway_s3_quota_objects",
+			"The maximum number of objects of the project in the region",
+			[]string{"region", "project_id"}, nil,
+		),
+		QuotaSize: prometheus.NewDesc(
+			"scaleway_s3_quota_size_bytes",
+			"The maximum storage size of the project in the region",
+			[]string{"region", "project_id"}, nil,
 		),
+		UsageObjects: prometheus.NewDesc(
+			"scaleway_s3_project_usage_objects",
+			"The number of objects of the project in the region",
+			[]string{"region", "project_id"}, nil,
+		),
+		UsageSize: prometheus.NewDesc(
+			"scaleway_s3_project_usage_size_bytes",
+			"The storage size used by the project in the region",
+			[]string{"region", "project_id"}, nil,
+		),
+	}
+}
+
+// TagLabelName turns a tag key into a valid label name.
+func TagLabelName(tag string) string {
+	var builder strings.Builder
+
+	builder.WriteString("tag_")
+
+	for _, char := range strings.ToLower(tag) {
+		if (char >= 'a' && char <= 'z') || (char >= '0' && char <= '9') || char == '_' {
+			builder.WriteRune(char)
+		} else {
+			builder.WriteRune('_')
+		}
 	}
+
+	return builder.String()
 }
 
 // Describe sends the super-set of all possible descriptors of metrics
 // collected by this Collector.
 func (c *BucketCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.ObjectCount
+	ch <- c.ClassCount
 	ch <- c.Bandwidth
+	ch <- c.BandwidthIn
 	ch <- c.StorageUsage
+	ch <- c.Info
+	ch <- c.InfoAge
+	ch <- c.BucketCount
+	ch <- c.Versioning
+	ch <- c.ObjectLock
+	ch <- c.Multiparts
+	ch <- c.Replication
+	ch <- c.Exposure
+	ch <- c.MultipartAge
+	ch <- c.QuotaBuckets
+	ch <- c.QuotaObjects
+	ch <- c.QuotaSize
+	ch <- c.UsageObjects
+	ch <- c.UsageSize
 }
 
 type BucketInfo struct {
@@ -139,6 +343,7 @@ const (
 	ObjectCount  MetricName = "object_count"
 	StorageUsage MetricName = "storage_usage"
 	BytesSent    MetricName = "bytes_sent"
+	BytesRecv    MetricName = "bytes_received"
 )
 
 type HandleSimpleMetricOptions struct {
@@ -147,6 +352,8 @@ type HandleSimpleMetricOptions struct {
 	Desc       *prometheus.Desc
 	labels     []string
 	Endpoint   Endpoint
+	// ClassDesc receives the timeseries broken down by storage class, if the API returns any
+	ClassDesc *prometheus.Desc
 }
 
 type HandleMultiMetricsOptions struct {
@@ -160,97 +367,230 @@ type HandleMultiMetricsOptions struct {
 
 // Collect is called by the Prometheus registry when collecting metrics.
 func (c *BucketCollector) Collect(ch chan<- prometheus.Metric) {
-	_, cancel := context.WithTimeout(context.Background(), c.timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
 	defer cancel()
 
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	// a failing region does not prevent the other ones to be collected
 	for _, endpoint := range c.endpoints {
-		buckets, err := endpoint.s3Client.ListBuckets(&s3.ListBucketsInput{})
+		wg.Add(1)
 
-		if err != nil {
-			c.errors.WithLabelValues("bucket").Add(1)
-			_ = level.Warn(c.logger).Log("msg", "can't fetch the list of buckets", "region", endpoint.region, "err", err)
+		go c.CollectRegion(ctx, &wg, ch, endpoint)
+	}
+}
 
-			return
-		}
+func (c *BucketCollector) CollectRegion(ctx context.Context, parentWg *sync.WaitGroup, ch chan<- prometheus.Metric, endpoint Endpoint) {
+	defer parentWg.Done()
+
+	buckets, err := CachedListing(c.cache, "bucket/"+endpoint.region.String(), func() (*s3.ListBucketsOutput, error) {
+		return endpoint.s3Client.ListBucketsWithContext(ctx, &s3.ListBucketsInput{})
+	})
+
+	if err != nil {
+		c.errors.WithLabelValues("bucket").Add(1)
+		_ = level.Warn(c.logger).Log("msg", "can't fetch the list of buckets", "region", endpoint.region, "err", err)
+
+		return
+	}
+
+	var bucketNames []string
+
+	for _, bucket := range buckets.Buckets {
+		bucketNames = append(bucketNames, *bucket.Name)
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.BucketCount, prometheus.GaugeValue, float64(len(bucketNames)), fmt.Sprint(endpoint.region))
+
+	// the S3 API only lists the buckets of the default project of the API key
+	ownerProjectID := strings.Split(*buckets.Owner.ID, ":")[0]
+
+	_ = level.Debug(c.logger).Log(
+		"msg", fmt.Sprintf("found %d buckets", len(bucketNames)),
+		"region", endpoint.region,
+		"bucketNames", fmt.Sprintf("%s", bucketNames),
+	)
+
+	projectIDs := []string{ownerProjectID}
+
+	if len(c.options.OrganizationIDs) > 0 && c.VerifyDiscovery(ctx, endpoint, ownerProjectID, bucketNames) {
+		projectIDs = append(projectIDs, c.FetchOtherProjects(ctx, endpoint, ownerProjectID)...)
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
 
-		scwReq := &scw.ScalewayRequest{
-			Method: "POST",
-			Path:   "/object-private/v1/regions/" + fmt.Sprint(endpoint.region) + "/buckets-info/",
+	for _, projectID := range projectIDs {
+		if !c.options.Projects.Match(projectID) {
+			continue
 		}
 
-		var bucketNames []string
+		if projectID == ownerProjectID {
+			c.CollectProject(ctx, &wg, ch, endpoint, projectID, bucketNames, true)
 
-		for _, bucket := range buckets.Buckets {
-			bucketNames = append(bucketNames, *bucket.Name)
+			continue
 		}
 
-		projectID := strings.Split(*buckets.Owner.ID, ":")[0]
+		// the buckets of the other projects are unknown, the private API returns all of them when no name is given
+		c.CollectProject(ctx, &wg, ch, endpoint, projectID, []string{}, false)
+	}
+}
 
-		_ = level.Debug(c.logger).Log(
-			"msg", fmt.Sprintf("found %d buckets", len(bucketNames)),
-			"region", endpoint.region,
-			"bucketNames", fmt.Sprintf("%s", bucketNames),
-		)
+// VerifyDiscovery tells whether the private API returns all the buckets of a project when no name is given,
+// by comparing its answer for the default project of the API key with the buckets listed by the S3 API.
+func (c *BucketCollector) VerifyDiscovery(ctx context.Context, endpoint Endpoint, ownerProjectID string, bucketNames []string) bool {
+	if len(bucketNames) == 0 {
+		_ = level.Debug(c.logger).Log("msg", "the discovery of the buckets of the other projects can't be verified without buckets in the default project", "region", endpoint.region)
 
-		err = scwReq.SetBody(&BucketInfoRequestBody{ProjectID: projectID, BucketsName: bucketNames})
+		return false
+	}
 
-		if err != nil {
-			c.errors.WithLabelValues("bucket").Add(1)
-			_ = level.Warn(c.logger).Log("msg", "can't fetch details of buckets", "region", endpoint.region, "err", err)
+	response, err := c.FetchBucketsInfo(ctx, endpoint, ownerProjectID, []string{})
 
-			return
+	if err != nil {
+		c.errors.WithLabelValues("bucket").Add(1)
+		_ = level.Warn(c.logger).Log("msg", "can't verify the discovery of the buckets of the other projects", "region", endpoint.region, "err", err)
+
+		return false
+	}
+
+	verified := len(response.Buckets) == len(bucketNames)
+
+	for _, name := range bucketNames {
+		if _, ok := response.Buckets[name]; !ok {
+			verified = false
 		}
+	}
 
-		var response BucketInfoList
+	if !verified {
+		_ = level.Warn(c.logger).Log("msg", "the private API doesn't return all the buckets of a project, only the default project of the API key is collected", "region", endpoint.region)
+	}
 
-		err = endpoint.client.Do(scwReq, &response)
+	return verified
+}
+
+// FetchOtherProjects returns the projects of the organizations other than the default project of the API key.
+func (c *BucketCollector) FetchOtherProjects(ctx context.Context, endpoint Endpoint, ownerProjectID string) []string {
+	var projectIDs []string
+
+	for _, organizationID := range c.options.OrganizationIDs {
+		projects, err := c.accountClient.ListProjects(&account.ListProjectsRequest{OrganizationID: organizationID}, scw.WithAllPages(), scw.WithContext(ctx))
 
 		if err != nil {
 			c.errors.WithLabelValues("bucket").Add(1)
-			_ = level.Warn(c.logger).Log("msg", "can't fetch details of buckets", "region", endpoint.region, "err", err)
+			_ = level.Warn(c.logger).Log("msg", "can't fetch the list of projects", "region", endpoint.region, "organization_id", organizationID, "err", err)
 
-			return
+			continue
 		}
 
-		var wg sync.WaitGroup
-		defer wg.Wait()
+		for _, project := range projects.Projects {
+			if project.ID != ownerProjectID {
+				projectIDs = append(projectIDs, project.ID)
+			}
+		}
+	}
 
-		for name, bucket := range response.Buckets {
-			wg.Add(1)
+	return projectIDs
+}
 
-			_ = level.Debug(c.logger).Log(
-				"msg", fmt.Sprintf("Fetching metrics for bucket : %s", name),
-				"region", endpoint.region,
-			)
-			go c.FetchMetricsForBucket(&wg, ch, name, bucket, endpoint)
-		}
+// FetchBucketsInfo returns the details of the given buckets of a project, or of all of them when no name is given.
+func (c *BucketCollector) FetchBucketsInfo(ctx context.Context, endpoint Endpoint, projectID string, bucketNames []string) (*BucketInfoList, error) {
+	scwReq := &scw.ScalewayRequest{
+		Method: "POST",
+		Path:   "/object-private/v1/regions/" + fmt.Sprint(endpoint.region) + "/buckets-info/",
+	}
+
+	err := scwReq.SetBody(&BucketInfoRequestBody{ProjectID: projectID, BucketsName: bucketNames})
+
+	if err != nil {
+		return nil, err
+	}
+
+	var response BucketInfoList
+
+	err = endpoint.client.Do(scwReq, &response, scw.WithContext(ctx))
+
+	if err != nil {
+		return nil, err
 	}
+
+	return &response, nil
 }
 
-func (c *BucketCollector) FetchMetricsForBucket(parentWg *sync.WaitGroup, ch chan<- prometheus.Metric, name string, bucket BucketInfo, endpoint Endpoint) {
+// CollectProject fetches the details of the buckets of a project, the S3 API is only queried for the buckets it owns.
+func (c *BucketCollector) CollectProject(ctx context.Context, wg *sync.WaitGroup, ch chan<- prometheus.Metric, endpoint Endpoint, projectID string, bucketNames []string, owned bool) {
+	response, err := c.FetchBucketsInfo(ctx, endpoint, projectID, bucketNames)
+
+	if err != nil {
+		c.errors.WithLabelValues("bucket").Add(1)
+		_ = level.Warn(c.logger).Log("msg", "can't fetch details of buckets", "region", endpoint.region, "project_id", projectID, "err", err)
+
+		return
+	}
+
+	region := fmt.Sprint(endpoint.region)
+
+	ch <- prometheus.MustNewConstMetric(c.QuotaBuckets, prometheus.GaugeValue, float64(response.QuotaBuckets), region, projectID)
+	ch <- prometheus.MustNewConstMetric(c.QuotaObjects, prometheus.GaugeValue, float64(response.QuotaObjects), region, projectID)
+	ch <- prometheus.MustNewConstMetric(c.QuotaSize, prometheus.GaugeValue, float64(response.QuotaSize), region, projectID)
+	ch <- prometheus.MustNewConstMetric(c.UsageObjects, prometheus.GaugeValue, float64(response.CurrentObjects), region, projectID)
+	ch <- prometheus.MustNewConstMetric(c.UsageSize, prometheus.GaugeValue, float64(response.CurrentSize), region, projectID)
+
+	for name, bucket := range response.Buckets {
+		wg.Add(1)
+
+		_ = level.Debug(c.logger).Log(
+			"msg", fmt.Sprintf("Fetching metrics for bucket : %s", name),
+			"region", endpoint.region,
+			"project_id", projectID,
+		)
+		go c.FetchMetricsForBucket(ctx, wg, ch, name, projectID, bucket, endpoint, owned)
+	}
+}
+
+func (c *BucketCollector) FetchMetricsForBucket(ctx context.Context, parentWg *sync.WaitGroup, ch chan<- prometheus.Metric, name string, projectID string, bucket BucketInfo, endpoint Endpoint, owned bool) {
 	defer parentWg.Done()
 
-	labels := []string{name, fmt.Sprint(endpoint.region), fmt.Sprint(bucket.IsPublic)}
+	labels := []string{name, fmt.Sprint(endpoint.region), fmt.Sprint(bucket.IsPublic), projectID}
 
-	// TODO check if it is possible to add bucket tag as labels
-	// for _, tags := range instance.Tags {
-	//     labels = append(labels, tags)
-	// }
+	c.CollectBucketInfo(ctx, ch, name, labels, endpoint, owned)
+
+	if !bucket.UpdatedAt.IsZero() {
+		ch <- prometheus.MustNewConstMetric(c.InfoAge, prometheus.GaugeValue, time.Since(bucket.UpdatedAt).Seconds(), labels...)
+	}
+
+	// the S3 client only reaches the buckets of the default project of the API key
+	if owned {
+		// each probe costs S3 requests for every bucket on every scrape, they are opt-in
+		c.CollectBucketProtection(ctx, ch, name, labels, endpoint)
+
+		if c.options.Probes["multipart"] {
+			c.CollectMultipartUploads(ctx, ch, name, labels, endpoint)
+		}
+
+		if c.options.Probes["replication"] {
+			c.CollectReplication(ctx, ch, name, labels, endpoint)
+		}
+
+		c.CollectPublicExposure(ctx, ch, name, labels, endpoint)
+	}
 
 	var wg sync.WaitGroup
 	defer wg.Wait()
 
-	wg.Add(3)
+	wg.Add(4)
 
-	go c.HandleSimpleMetric(&wg, ch, &HandleSimpleMetricOptions{
+	go c.HandleSimpleMetric(ctx, &wg, ch, &HandleSimpleMetricOptions{
 		Bucket:     name,
 		MetricName: ObjectCount,
 		labels:     labels,
 		Desc:       c.ObjectCount,
 		Endpoint:   endpoint,
+		ClassDesc:  c.ClassCount,
 	})
 
-	go c.HandleSimpleMetric(&wg, ch, &HandleSimpleMetricOptions{
+	go c.HandleSimpleMetric(ctx, &wg, ch, &HandleSimpleMetricOptions{
 		Bucket:     name,
 		MetricName: BytesSent,
 		labels:     labels,
@@ -258,7 +598,15 @@ func (c *BucketCollector) FetchMetricsForBucket(parentWg *sync.WaitGroup, ch cha
 		Endpoint:   endpoint,
 	})
 
-	go c.HandleMultiMetrics(&wg, ch, &HandleMultiMetricsOptions{
+	go c.HandleSimpleMetric(ctx, &wg, ch, &HandleSimpleMetricOptions{
+		Bucket:     name,
+		MetricName: BytesRecv,
+		labels:     labels,
+		Desc:       c.BandwidthIn,
+		Endpoint:   endpoint,
+	})
+
+	go c.HandleMultiMetrics(ctx, &wg, ch, &HandleMultiMetricsOptions{
 		Bucket:     name,
 		MetricName: StorageUsage,
 		labels:     labels,
@@ -270,12 +618,311 @@ func (c *BucketCollector) FetchMetricsForBucket(parentWg *sync.WaitGroup, ch cha
 	})
 }
 
-func (c *BucketCollector) HandleSimpleMetric(parentWg *sync.WaitGroup, ch chan<- prometheus.Metric, options *HandleSimpleMetricOptions) {
+func (c *BucketCollector) CollectBucketInfo(ctx context.Context, ch chan<- prometheus.Metric, name string, labels []string, endpoint Endpoint, owned bool) {
+	labelsInfo := append([]string{}, labels...)
+
+	if len(c.options.TagLabels) == 0 && len(c.options.MappedTagLabels) == 0 {
+		ch <- prometheus.MustNewConstMetric(c.Info, prometheus.GaugeValue, 1.0, labelsInfo...)
+
+		return
+	}
+
+	tags := make(map[string]string)
+
+	var tagging *s3.GetBucketTaggingOutput
+
+	var err error
+
+	// the tags of the buckets of the other projects can't be read, their tag labels are left empty
+	if owned {
+		tagging, err = endpoint.s3Client.GetBucketTaggingWithContext(ctx, &s3.GetBucketTaggingInput{Bucket: aws.String(name)})
+	}
+
+	var awsError awserr.Error
+
+	switch {
+	case !owned:
+	case err == nil:
+		for _, tag := range tagging.TagSet {
+			tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+		}
+	case errors.As(err, &awsError) && awsError.Code() == "NoSuchTagSet":
+		// the bucket has no tags
+	default:
+		c.errors.WithLabelValues("bucket").Add(1)
+		_ = level.Warn(c.logger).Log("msg", "can't fetch the tags of the bucket", "region", endpoint.region, "bucket", name, "err", err)
+
+		return
+	}
+
+	for _, tag := range c.options.TagLabels {
+		labelsInfo = append(labelsInfo, tags[tag])
+	}
+
+	labelsInfo = append(labelsInfo, c.options.MappedTagLabels.KeyValues(tags)...)
+
+	ch <- prometheus.MustNewConstMetric(c.Info, prometheus.GaugeValue, 1.0, labelsInfo...)
+}
+
+func (c *BucketCollector) CollectBucketProtection(ctx context.Context, ch chan<- prometheus.Metric, name string, labels []string, endpoint Endpoint) {
+	if c.options.Probes["versioning"] {
+		c.CollectVersioning(ctx, ch, name, labels, endpoint)
+	}
+
+	if c.options.Probes["object_lock"] {
+		c.CollectObjectLock(ctx, ch, name, labels, endpoint)
+	}
+}
+
+func (c *BucketCollector) CollectVersioning(ctx context.Context, ch chan<- prometheus.Metric, name string, labels []string, endpoint Endpoint) {
+	versioning, err := endpoint.s3Client.GetBucketVersioningWithContext(ctx, &s3.GetBucketVersioningInput{Bucket: aws.String(name)})
+
+	if err != nil {
+		c.errors.WithLabelValues("bucket").Add(1)
+		_ = level.Warn(c.logger).Log("msg", "can't fetch the versioning of the bucket", "region", endpoint.region, "bucket", name, "err", err)
+	} else {
+		status := aws.StringValue(versioning.Status)
+
+		var enabled float64
+
+		if status == s3.BucketVersioningStatusEnabled {
+			enabled = 1.0
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.Versioning, prometheus.GaugeValue, enabled, append(append([]string{}, labels...), status)...)
+	}
+}
+
+func (c *BucketCollector) CollectObjectLock(ctx context.Context, ch chan<- prometheus.Metric, name string, labels []string, endpoint Endpoint) {
+	objectLock, err := endpoint.s3Client.GetObjectLockConfigurationWithContext(ctx, &s3.GetObjectLockConfigurationInput{Bucket: aws.String(name)})
+
+	var awsError awserr.Error
+
+	switch {
+	case err == nil:
+		var enabled float64
+
+		var mode string
+
+		if objectLock.ObjectLockConfiguration != nil {
+			configuration := objectLock.ObjectLockConfiguration
+
+			if aws.StringValue(configuration.ObjectLockEnabled) == s3.ObjectLockEnabledEnabled {
+				enabled = 1.0
+			}
+
+			if configuration.Rule != nil && configuration.Rule.DefaultRetention != nil {
+				mode = aws.StringValue(configuration.Rule.DefaultRetention.Mode)
+			}
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.ObjectLock, prometheus.GaugeValue, enabled, append(append([]string{}, labels...), mode)...)
+	case errors.As(err, &awsError) && awsError.Code() == "ObjectLockConfigurationNotFoundError":
+		ch <- prometheus.MustNewConstMetric(c.ObjectLock, prometheus.GaugeValue, 0.0, append(append([]string{}, labels...), "")...)
+	default:
+		c.errors.WithLabelValues("bucket").Add(1)
+		_ = level.Warn(c.logger).Log("msg", "can't fetch the object lock configuration of the bucket", "region", endpoint.region, "bucket", name, "err", err)
+	}
+}
+
+func (c *BucketCollector) CollectMultipartUploads(ctx context.Context, ch chan<- prometheus.Metric, name string, labels []string, endpoint Endpoint) {
+	var count int
+
+	var oldest *time.Time
+
+	err := endpoint.s3Client.ListMultipartUploadsPagesWithContext(ctx, &s3.ListMultipartUploadsInput{Bucket: aws.String(name)}, func(page *s3.ListMultipartUploadsOutput, _ bool) bool {
+		for _, upload := range page.Uploads {
+			count++
+
+			if upload.Initiated != nil && (oldest == nil || upload.Initiated.Before(*oldest)) {
+				oldest = upload.Initiated
+			}
+		}
+
+		return true
+	})
+
+	if err != nil {
+		c.errors.WithLabelValues("bucket").Add(1)
+		_ = level.Warn(c.logger).Log("msg", "can't fetch the multipart uploads of the bucket", "region", endpoint.region, "bucket", name, "err", err)
+
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.Multiparts, prometheus.GaugeValue, float64(count), labels...)
+
+	if oldest != nil {
+		ch <- prometheus.MustNewConstMetric(c.MultipartAge, prometheus.GaugeValue, time.Since(*oldest).Seconds(), labels...)
+	}
+}
+
+func (c *BucketCollector) CollectReplication(ctx context.Context, ch chan<- prometheus.Metric, name string, labels []string, endpoint Endpoint) {
+	replication, err := endpoint.s3Client.GetBucketReplicationWithContext(ctx, &s3.GetBucketReplicationInput{Bucket: aws.String(name)})
+
+	var awsError awserr.Error
+
+	switch {
+	case err == nil:
+	case errors.As(err, &awsError) && awsError.Code() == "ReplicationConfigurationNotFoundError":
+		ch <- prometheus.MustNewConstMetric(c.Replication, prometheus.GaugeValue, 0.0, append(append([]string{}, labels...), "", "")...)
+
+		return
+	default:
+		c.errors.WithLabelValues("bucket").Add(1)
+		_ = level.Warn(c.logger).Log("msg", "can't fetch the replication configuration of the bucket", "region", endpoint.region, "bucket", name, "err", err)
+
+		return
+	}
+
+	if replication.ReplicationConfiguration == nil {
+		return
+	}
+
+	// several rules can replicate to the same bucket, it is enabled as soon as one of them is
+	destinations := make(map[string]float64)
+
+	for _, rule := range replication.ReplicationConfiguration.Rules {
+		if rule.Destination == nil {
+			continue
+		}
+
+		// the destination is an ARN, e.g. arn:scw:s3:::bucket
+		destination := aws.StringValue(rule.Destination.Bucket)
+		destination = destination[strings.LastIndex(destination, ":")+1:]
+
+		if _, ok := destinations[destination]; !ok {
+			destinations[destination] = 0.0
+		}
+
+		if aws.StringValue(rule.Status) == s3.ReplicationRuleStatusEnabled {
+			destinations[destination] = 1.0
+		}
+	}
+
+	for destination, enabled := range destinations {
+		var destinationRegion string
+
+		location, errLocation := endpoint.s3Client.GetBucketLocationWithContext(ctx, &s3.GetBucketLocationInput{Bucket: aws.String(destination)})
+
+		if errLocation == nil {
+			destinationRegion = aws.StringValue(location.LocationConstraint)
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.Replication, prometheus.GaugeValue, enabled, append(append([]string{}, labels...), destination, destinationRegion)...)
+	}
+}
+
+// BucketPolicy is the subset of a bucket policy needed to tell whether it grants a public read access.
+type BucketPolicy struct {
+	Statement []struct {
+		Effect    string          `json:"Effect"`
+		Principal json.RawMessage `json:"Principal"`
+		Action    json.RawMessage `json:"Action"`
+	} `json:"Statement"`
+}
+
+// GrantsPublicRead tells whether a statement allows anyone to read the objects.
+func (p *BucketPolicy) GrantsPublicRead() bool {
+	for _, statement := range p.Statement {
+		if statement.Effect != "Allow" || !strings.Contains(string(statement.Principal), `"*"`) {
+			continue
+		}
+
+		action := string(statement.Action)
+
+		if strings.Contains(action, `"s3:GetObject"`) || strings.Contains(action, `"s3:*"`) || strings.Contains(action, `"*"`) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *BucketCollector) CollectPublicExposure(ctx context.Context, ch chan<- prometheus.Metric, name string, labels []string, endpoint Endpoint) {
+	if c.options.Probes["acl"] {
+		c.CollectACLExposure(ctx, ch, name, labels, endpoint)
+	}
+
+	if c.options.Probes["policy"] {
+		c.CollectPolicyExposure(ctx, ch, name, labels, endpoint)
+	}
+
+	if c.options.Probes["website"] {
+		c.CollectWebsiteExposure(ctx, ch, name, labels, endpoint)
+	}
+}
+
+func (c *BucketCollector) CollectACLExposure(ctx context.Context, ch chan<- prometheus.Metric, name string, labels []string, endpoint Endpoint) {
+	acl, err := endpoint.s3Client.GetBucketAclWithContext(ctx, &s3.GetBucketAclInput{Bucket: aws.String(name)})
+
+	if err != nil {
+		c.errors.WithLabelValues("bucket").Add(1)
+		_ = level.Warn(c.logger).Log("msg", "can't fetch the ACL of the bucket", "region", endpoint.region, "bucket", name, "err", err)
+	} else {
+		var exposed float64
+
+		for _, grant := range acl.Grants {
+			if grant.Grantee == nil {
+				continue
+			}
+
+			uri := aws.StringValue(grant.Grantee.URI)
+
+			if strings.HasSuffix(uri, "/global/AllUsers") || strings.HasSuffix(uri, "/global/AuthenticatedUsers") {
+				exposed = 1.0
+			}
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.Exposure, prometheus.GaugeValue, exposed, append(append([]string{}, labels...), "acl")...)
+	}
+}
+
+func (c *BucketCollector) CollectPolicyExposure(ctx context.Context, ch chan<- prometheus.Metric, name string, labels []string, endpoint Endpoint) {
+	var awsError awserr.Error
+
+	policy, err := endpoint.s3Client.GetBucketPolicyWithContext(ctx, &s3.GetBucketPolicyInput{Bucket: aws.String(name)})
+
+	switch {
+	case err == nil:
+		var bucketPolicy BucketPolicy
+
+		var exposed float64
+
+		if json.Unmarshal([]byte(aws.StringValue(policy.Policy)), &bucketPolicy) == nil && bucketPolicy.GrantsPublicRead() {
+			exposed = 1.0
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.Exposure, prometheus.GaugeValue, exposed, append(append([]string{}, labels...), "policy")...)
+	case errors.As(err, &awsError) && awsError.Code() == "NoSuchBucketPolicy":
+		ch <- prometheus.MustNewConstMetric(c.Exposure, prometheus.GaugeValue, 0.0, append(append([]string{}, labels...), "policy")...)
+	default:
+		c.errors.WithLabelValues("bucket").Add(1)
+		_ = level.Warn(c.logger).Log("msg", "can't fetch the policy of the bucket", "region", endpoint.region, "bucket", name, "err", err)
+	}
+}
+
+func (c *BucketCollector) CollectWebsiteExposure(ctx context.Context, ch chan<- prometheus.Metric, name string, labels []string, endpoint Endpoint) {
+	var awsError awserr.Error
+
+	_, err := endpoint.s3Client.GetBucketWebsiteWithContext(ctx, &s3.GetBucketWebsiteInput{Bucket: aws.String(name)})
+
+	switch {
+	case err == nil:
+		ch <- prometheus.MustNewConstMetric(c.Exposure, prometheus.GaugeValue, 1.0, append(append([]string{}, labels...), "website")...)
+	case errors.As(err, &awsError) && awsError.Code() == "NoSuchWebsiteConfiguration":
+		ch <- prometheus.MustNewConstMetric(c.Exposure, prometheus.GaugeValue, 0.0, append(append([]string{}, labels...), "website")...)
+	default:
+		c.errors.WithLabelValues("bucket").Add(1)
+		_ = level.Warn(c.logger).Log("msg", "can't fetch the website configuration of the bucket", "region", endpoint.region, "bucket", name, "err", err)
+	}
+}
+
+func (c *BucketCollector) HandleSimpleMetric(ctx context.Context, parentWg *sync.WaitGroup, ch chan<- prometheus.Metric, options *HandleSimpleMetricOptions) {
 	defer parentWg.Done()
 
 	var response Metric
 
-	err := c.FetchMetric(options.Bucket, options.MetricName, &response, options.Endpoint)
+	err := c.FetchMetric(ctx, options.Bucket, options.MetricName, &response, options.Endpoint)
 
 	if err != nil {
 		c.errors.WithLabelValues("bucket").Add(1)
@@ -290,6 +937,13 @@ func (c *BucketCollector) HandleSimpleMetric(parentWg *sync.WaitGroup, ch chan<-
 		return
 	}
 
+	// the total is computed from the storage classes when the API only returns the broken down timeseries
+	var classTotal float64
+
+	var hasClasses, hasTotal bool
+
+	var classPoint *scw.TimeSeriesPoint
+
 	for _, timeseries := range response.Timeseries {
 		sort.Slice(timeseries.Points, func(i, j int) bool {
 			return timeseries.Points[i].Timestamp.Before(timeseries.Points[j].Timestamp)
@@ -308,18 +962,38 @@ func (c *BucketCollector) HandleSimpleMetric(parentWg *sync.WaitGroup, ch chan<-
 			continue
 		}
 
-		value := float64(timeseries.Points[len(timeseries.Points)-1].Value)
+		point := timeseries.Points[len(timeseries.Points)-1]
+		value := float64(point.Value)
+
+		if storageClass := timeseries.Metadata["type"]; options.ClassDesc != nil && storageClass != "" {
+			ch <- withPointTimestamp(prometheus.MustNewConstMetric(options.ClassDesc, prometheus.GaugeValue, value, append(append([]string{}, options.labels...), storageClass)...), point, c.options.Timestamps)
+
+			classTotal += value
+			hasClasses = true
+
+			if classPoint == nil || point.Timestamp.After(classPoint.Timestamp) {
+				classPoint = point
+			}
+
+			continue
+		}
+
+		hasTotal = true
+
+		ch <- withPointTimestamp(prometheus.MustNewConstMetric(options.Desc, prometheus.GaugeValue, value, options.labels...), point, c.options.Timestamps)
+	}
 
-		ch <- prometheus.MustNewConstMetric(options.Desc, prometheus.GaugeValue, value, options.labels...)
+	if hasClasses && !hasTotal {
+		ch <- withPointTimestamp(prometheus.MustNewConstMetric(options.Desc, prometheus.GaugeValue, classTotal, options.labels...), classPoint, c.options.Timestamps)
 	}
 }
 
-func (c *BucketCollector) HandleMultiMetrics(parentWg *sync.WaitGroup, ch chan<- prometheus.Metric, options *HandleMultiMetricsOptions) {
+func (c *BucketCollector) HandleMultiMetrics(ctx context.Context, parentWg *sync.WaitGroup, ch chan<- prometheus.Metric, options *HandleMultiMetricsOptions) {
 	defer parentWg.Done()
 
 	var response Metric
 
-	err := c.FetchMetric(options.Bucket, options.MetricName, &response, options.Endpoint)
+	err := c.FetchMetric(ctx, options.Bucket, options.MetricName, &response, options.Endpoint)
 
 	if err != nil {
 		c.errors.WithLabelValues("bucket").Add(1)
@@ -355,15 +1029,15 @@ func (c *BucketCollector) HandleMultiMetrics(parentWg *sync.WaitGroup, ch chan<-
 			continue
 		}
 
-		value := float64(timeseries.Points[len(timeseries.Points)-1].Value)
+		point := timeseries.Points[len(timeseries.Points)-1]
 
 		allLabels := append(append([]string{}, options.labels...), extraLabel)
 
-		ch <- prometheus.MustNewConstMetric(options.Desc, prometheus.GaugeValue, value, allLabels...)
+		ch <- withPointTimestamp(prometheus.MustNewConstMetric(options.Desc, prometheus.GaugeValue, float64(point.Value), allLabels...), point, c.options.Timestamps)
 	}
 }
 
-func (c *BucketCollector) FetchMetric(bucket string, metricName MetricName, response *Metric, endpoint Endpoint) error {
+func (c *BucketCollector) FetchMetric(ctx context.Context, bucket string, metricName MetricName, response *Metric, endpoint Endpoint) error {
 	query := url.Values{}
 
 	query.Add("start_date", time.Now().Add(-1*time.Hour).Format(time.RFC3339))
@@ -376,7 +1050,7 @@ func (c *BucketCollector) FetchMetric(bucket string, metricName MetricName, resp
 		Query:  query,
 	}
 
-	err := endpoint.client.Do(scwReq, &response)
+	err := endpoint.client.Do(scwReq, &response, scw.WithContext(ctx))
 
 	if err != nil {
 		return err
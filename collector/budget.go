@@ -0,0 +1,70 @@
+package collector
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	billing "github.com/scaleway/scaleway-sdk-go/api/billing/v2beta1"
+)
+
+// Budget is a monthly spending limit, restricted to an organization, a project or a category when they are set.
+type Budget struct {
+	Name           string  `json:"name"`
+	OrganizationID string  `json:"organization_id"`
+	ProjectID      string  `json:"project_id"`
+	Category       string  `json:"category"`
+	Limit          float64 `json:"limit"`
+	Currency       string  `json:"currency"`
+}
+
+// Matches tells whether the consumption counts toward the budget.
+func (b *Budget) Matches(organizationID string, consumption *billing.ListConsumptionsResponseConsumption) bool {
+	return (b.OrganizationID == "" || b.OrganizationID == organizationID) &&
+		(b.ProjectID == "" || b.ProjectID == consumption.ProjectID) &&
+		(b.Category == "" || strings.EqualFold(b.Category, consumption.CategoryName))
+}
+
+// LoadBudgets reads a JSON file holding a list of budgets.
+func LoadBudgets(path string) ([]*Budget, error) {
+	content, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, fmt.Errorf("can't read the budgets file: %w", err)
+	}
+
+	var budgets []*Budget
+
+	if err := json.Unmarshal(content, &budgets); err != nil {
+		return nil, fmt.Errorf("can't parse the budgets file: %w", err)
+	}
+
+	names := make(map[string]bool)
+
+	for _, budget := range budgets {
+		if budget.Name == "" {
+			return nil, errors.New("a budget is missing its name")
+		}
+
+		// the budgets are told apart by their name label
+		if names[budget.Name] {
+			return nil, fmt.Errorf("the budget %s is declared twice", budget.Name)
+		}
+
+		names[budget.Name] = true
+
+		if budget.Limit <= 0 {
+			return nil, fmt.Errorf("the limit of the budget %s must be positive", budget.Name)
+		}
+
+		if budget.Currency == "" {
+			return nil, fmt.Errorf("the budget %s is missing its currency", budget.Name)
+		}
+
+		budget.Currency = strings.ToUpper(budget.Currency)
+	}
+
+	return budgets, nil
+}
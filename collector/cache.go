@@ -0,0 +1,57 @@
+package collector
+
+import (
+	"sync"
+	"time"
+)
+
+// ListingCache keeps the resource listings fetched from the Scaleway API for a while,
+// so the resources are not enumerated again on every scrape.
+type ListingCache struct {
+	ttl     time.Duration
+	mutex   sync.Mutex
+	entries map[string]listingCacheEntry
+}
+
+type listingCacheEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+// NewListingCache returns a new ListingCache, the listings are not cached when the TTL is zero.
+func NewListingCache(ttl time.Duration) *ListingCache {
+	return &ListingCache{
+		ttl:     ttl,
+		entries: map[string]listingCacheEntry{},
+	}
+}
+
+// CachedListing returns the listing stored in the cache under the key, it is fetched again once expired.
+// The errors are not cached.
+func CachedListing[T any](cache *ListingCache, key string, fetch func() (T, error)) (T, error) {
+	if cache == nil || cache.ttl <= 0 {
+		return fetch()
+	}
+
+	cache.mutex.Lock()
+	entry, found := cache.entries[key]
+	cache.mutex.Unlock()
+
+	if found && time.Now().Before(entry.expiresAt) {
+		if cached, isListing := entry.value.(T); isListing {
+			return cached, nil
+		}
+	}
+
+	value, err := fetch()
+
+	if err != nil {
+		return value, err
+	}
+
+	cache.mutex.Lock()
+	cache.entries[key] = listingCacheEntry{value: value, expiresAt: time.Now().Add(cache.ttl)}
+	cache.mutex.Unlock()
+
+	return value, nil
+}
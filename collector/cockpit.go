@@ -0,0 +1,118 @@
+package collector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// CockpitClient queries the Prometheus API of a Cockpit metrics data source.
+type CockpitClient struct {
+	url        string
+	token      string
+	httpClient *http.Client
+}
+
+// CockpitQueryResponse is the response of an instant query of the Prometheus API.
+type CockpitQueryResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		Result []struct {
+			Metric map[string]string `json:"metric"`
+			Value  []interface{}     `json:"value"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// NewCockpitClient returns a new CockpitClient for the data source URL, e.g. https://<id>.metrics.cockpit.fr-par.scw.cloud.
+func NewCockpitClient(dataSourceURL string, token string, timeout time.Duration) *CockpitClient {
+	return &CockpitClient{
+		url:        strings.TrimSuffix(dataSourceURL, "/"),
+		token:      token,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// Query runs an instant query and returns its samples as timeseries holding a single point,
+// the __name__ label is used as the name of the timeseries and the other labels as its metadata.
+func (c *CockpitClient) Query(ctx context.Context, query string) ([]*scw.TimeSeries, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+"/prometheus/api/v1/query?"+url.Values{"query": {query}}.Encode(), nil)
+
+	if err != nil {
+		return nil, fmt.Errorf("can't create the Cockpit query: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.httpClient.Do(req)
+
+	if err != nil {
+		return nil, fmt.Errorf("can't query Cockpit: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	var response CockpitQueryResponse
+
+	if errDecode := json.NewDecoder(resp.Body).Decode(&response); errDecode != nil {
+		return nil, fmt.Errorf("can't decode the Cockpit response (status %d): %w", resp.StatusCode, errDecode)
+	}
+
+	if response.Status != "success" {
+		return nil, errors.New("the Cockpit query failed: " + response.Error)
+	}
+
+	timeseries := make([]*scw.TimeSeries, 0, len(response.Data.Result))
+
+	for _, sample := range response.Data.Result {
+		if len(sample.Value) != 2 {
+			continue
+		}
+
+		timestamp, ok := sample.Value[0].(float64)
+
+		if !ok {
+			continue
+		}
+
+		raw, ok := sample.Value[1].(string)
+
+		if !ok {
+			continue
+		}
+
+		value, errParse := strconv.ParseFloat(raw, 32)
+
+		if errParse != nil {
+			continue
+		}
+
+		metadata := map[string]string{}
+
+		for name, labelValue := range sample.Metric {
+			if name != "__name__" {
+				metadata[name] = labelValue
+			}
+		}
+
+		seconds, fraction := math.Modf(timestamp)
+
+		timeseries = append(timeseries, &scw.TimeSeries{
+			Name:     sample.Metric["__name__"],
+			Metadata: metadata,
+			Points:   []*scw.TimeSeriesPoint{{Timestamp: time.Unix(int64(seconds), int64(fraction*1e9)), Value: float32(value)}},
+		})
+	}
+
+	return timeseries, nil
+}
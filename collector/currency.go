@@ -0,0 +1,67 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// CurrencyRates converts an amount from a currency into another.
+type CurrencyRates interface {
+	Convert(value float64, from string, to string) (float64, bool)
+}
+
+// StaticCurrencyRates holds the value of one unit of each currency in a common reference currency.
+type StaticCurrencyRates map[string]float64
+
+// LoadStaticCurrencyRates reads a JSON file mapping the currency codes to their value in a common reference currency, e.g. {"EUR": 1, "USD": 0.92}.
+func LoadStaticCurrencyRates(path string) (StaticCurrencyRates, error) {
+	content, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, fmt.Errorf("can't read the currency rates file: %w", err)
+	}
+
+	var rates StaticCurrencyRates
+
+	if err := json.Unmarshal(content, &rates); err != nil {
+		return nil, fmt.Errorf("can't parse the currency rates file: %w", err)
+	}
+
+	normalized := make(StaticCurrencyRates, len(rates))
+
+	for currency, rate := range rates {
+		if rate <= 0 {
+			return nil, fmt.Errorf("the rate of %s must be positive", currency)
+		}
+
+		normalized[strings.ToUpper(currency)] = rate
+	}
+
+	return normalized, nil
+}
+
+// Convert implements CurrencyRates.
+func (r StaticCurrencyRates) Convert(value float64, from string, to string) (float64, bool) {
+	from = strings.ToUpper(from)
+	to = strings.ToUpper(to)
+
+	if from == to {
+		return value, true
+	}
+
+	fromRate, ok := r[from]
+
+	if !ok {
+		return 0, false
+	}
+
+	toRate, ok := r[to]
+
+	if !ok {
+		return 0, false
+	}
+
+	return value * fromRate / toRate, true
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strconv"
 	"sync"
 	"time"
 
@@ -16,43 +17,88 @@ import (
 
 // DatabaseCollector collects metrics about all databases.
 type DatabaseCollector struct {
-	logger    log.Logger
-	errors    *prometheus.CounterVec
-	client    *scw.Client
-	rdbClient *rdb.API
-	timeout   time.Duration
-	regions   []scw.Region
+	logger      log.Logger
+	errors      *prometheus.CounterVec
+	client      *scw.Client
+	rdbClient   *rdb.API
+	timeout     time.Duration
+	regions     []scw.Region
+	unmapped    bool
+	passthrough *PassthroughMetrics
+	timestamps  bool
+	projects    ProjectFilter
+	tags        TagFilter
+	cache       *ListingCache
+	tagLabels   TagLabels
 
 	Up         *prometheus.Desc
+	Info       *prometheus.Desc
 	CPUs       *prometheus.Desc
 	Memory     *prometheus.Desc
 	Connection *prometheus.Desc
 	Disk       *prometheus.Desc
+	VolumeSize *prometheus.Desc
+	VolumeInfo *prometheus.Desc
+
+	ReadReplicas         *prometheus.Desc
+	ReadReplicaUp        *prometheus.Desc
+	ReadReplicaEndpoints *prometheus.Desc
+	Endpoint             *prometheus.Desc
+	Maintenances         *prometheus.Desc
+	MaintenanceStart     *prometheus.Desc
+	MaintenanceStop      *prometheus.Desc
+	MaxConnections       *prometheus.Desc
+	EngineVersion        *prometheus.Desc
+	EngineEndOfLife      *prometheus.Desc
+	UpgradeAvailable     *prometheus.Desc
+	LogicalDatabases     *prometheus.Desc
+	Users                *prometheus.Desc
+	Encryption           *prometheus.Desc
+	Snapshots            *prometheus.Desc
+	SnapshotsSize        *prometheus.Desc
+	SnapshotOldestAge    *prometheus.Desc
+	SnapshotNewestAge    *prometheus.Desc
+	ACLRules             *prometheus.Desc
+	ACLOpenToWorld       *prometheus.Desc
 }
 
 // NewDatabaseCollector returns a new DatabaseCollector.
-func NewDatabaseCollector(logger log.Logger, errors *prometheus.CounterVec, client *scw.Client, timeout time.Duration, regions []scw.Region) *DatabaseCollector {
+func NewDatabaseCollector(logger log.Logger, errors *prometheus.CounterVec, client *scw.Client, timeout time.Duration, regions []scw.Region, projects ProjectFilter, tags TagFilter, cache *ListingCache, unmapped bool, timestamps bool, tagLabels TagLabels) *DatabaseCollector {
 	errors.WithLabelValues("database").Add(0)
 
 	_ = level.Info(logger).Log("msg", "Database collector enabled")
 
-	labels := []string{"id", "name", "region", "engine", "type"}
+	labels := []string{"id", "name", "region", "project_id", "engine", "type"}
 
 	labelsNode := []string{"id", "name", "node"}
 
+	labelsReplica := []string{"id", "name", "region", "replica_id"}
+
 	return &DatabaseCollector{
-		logger:    logger,
-		errors:    errors,
-		client:    client,
-		rdbClient: rdb.NewAPI(client),
-		timeout:   timeout,
-		regions:   regions,
+		logger:      logger,
+		errors:      errors,
+		client:      client,
+		rdbClient:   rdb.NewAPI(client),
+		timeout:     timeout,
+		regions:     regions,
+		projects:    projects,
+		tags:        tags,
+		cache:       cache,
+		unmapped:    unmapped,
+		passthrough: NewPassthroughMetrics(),
+		timestamps:  timestamps,
+		tagLabels:   tagLabels,
 
 		Up: prometheus.NewDesc(
 			"scaleway_database_up",
 			"If 1 the database is up and running, 0.5 in autohealing, 0 otherwise",
 			labels, nil,
 		),
+		Info: prometheus.NewDesc(
+			"scaleway_database_info",
+			"Database's information",
+			append([]string{"id", "name", "region", "project_id", "engine", "version", "node_type", "ha_enabled", "backup_disabled", "backup_frequency_hours", "backup_retention_days"}, tagLabels.Names()...), nil,
+		),
 		CPUs: prometheus.NewDesc(
 			"scaleway_database_cpu_usage_percent",
 			"Database's CPUs percentage usage",
@@ -73,6 +119,116 @@ func NewDatabaseCollector(logger log.Logger, errors *prometheus.CounterVec, clie
 			"Database's disk percentage usage",
 			labelsNode, nil,
 		),
+		VolumeSize: prometheus.NewDesc(
+			"scaleway_database_volume_size_bytes",
+			"Database's volume size in bytes",
+			labels, nil,
+		),
+		VolumeInfo: prometheus.NewDesc(
+			"scaleway_database_volume_info",
+			"Database's volume information",
+			append(append([]string{}, labels...), "volume_type"), nil,
+		),
+		ReadReplicas: prometheus.NewDesc(
+			"scaleway_database_read_replicas",
+			"Database's read replica count",
+			labels, nil,
+		),
+		ReadReplicaUp: prometheus.NewDesc(
+			"scaleway_database_read_replica_up",
+			"If 1 the read replica is up and running, 0.5 when being configured, 0 otherwise",
+			append(append([]string{}, labelsReplica...), "status"), nil,
+		),
+		ReadReplicaEndpoints: prometheus.NewDesc(
+			"scaleway_database_read_replica_endpoints",
+			"Read replica's endpoint count",
+			labelsReplica, nil,
+		),
+		Maintenances: prometheus.NewDesc(
+			"scaleway_database_pending_maintenances",
+			"Database's pending maintenance count",
+			labels, nil,
+		),
+		MaintenanceStart: prometheus.NewDesc(
+			"scaleway_database_maintenance_start_timestamp_seconds",
+			"Start of the window of a pending maintenance of the database",
+			[]string{"id", "name", "region", "reason"}, nil,
+		),
+		MaintenanceStop: prometheus.NewDesc(
+			"scaleway_database_maintenance_stop_timestamp_seconds",
+			"End of the window of a pending maintenance of the database",
+			[]string{"id", "name", "region", "reason"}, nil,
+		),
+		MaxConnections: prometheus.NewDesc(
+			"scaleway_database_max_connections",
+			"Database's maximum connection count, from its settings or the default of its engine",
+			labels, nil,
+		),
+		EngineVersion: prometheus.NewDesc(
+			"scaleway_database_engine_version_info",
+			"Version of the engine running the database",
+			[]string{"id", "name", "region", "engine", "version"}, nil,
+		),
+		EngineEndOfLife: prometheus.NewDesc(
+			"scaleway_database_engine_end_of_life_timestamp_seconds",
+			"End of life of the engine version running the database",
+			labels, nil,
+		),
+		UpgradeAvailable: prometheus.NewDesc(
+			"scaleway_database_upgrade_available",
+			"If 1 a newer minor or major version of the engine is available for the database, 0 otherwise",
+			append(append([]string{}, labels...), "kind"), nil,
+		),
+		LogicalDatabases: prometheus.NewDesc(
+			"scaleway_database_logical_databases_total",
+			"Number of logical databases within the database instance",
+			labels, nil,
+		),
+		Users: prometheus.NewDesc(
+			"scaleway_database_users_total",
+			"Number of users of the database instance",
+			labels, nil,
+		),
+		Encryption: prometheus.NewDesc(
+			"scaleway_database_encryption_at_rest_enabled",
+			"If 1 the volume of the database is encrypted at rest, 0 otherwise",
+			labels, nil,
+		),
+		Snapshots: prometheus.NewDesc(
+			"scaleway_database_snapshots",
+			"Database's snapshot count",
+			labels, nil,
+		),
+		SnapshotsSize: prometheus.NewDesc(
+			"scaleway_database_snapshots_size_bytes",
+			"Total size of the snapshots of the database",
+			labels, nil,
+		),
+		SnapshotOldestAge: prometheus.NewDesc(
+			"scaleway_database_snapshot_oldest_age_seconds",
+			"Age of the oldest snapshot of the database",
+			labels, nil,
+		),
+		SnapshotNewestAge: prometheus.NewDesc(
+			"scaleway_database_snapshot_newest_age_seconds",
+			"Age of the newest snapshot of the database",
+			labels, nil,
+		),
+		ACLRules: prometheus.NewDesc(
+			"scaleway_database_acl_rules",
+			"Database's ACL rule count",
+			labels, nil,
+		),
+		ACLOpenToWorld: prometheus.NewDesc(
+			"scaleway_database_acl_open_to_world",
+			"If 1 an ACL rule of the database allows any IP (0.0.0.0/0), 0 otherwise",
+			labels, nil,
+		),
+		Endpoint: prometheus.NewDesc(
+			"scaleway_database_endpoint_info",
+			"Database's endpoint information, the type is load_balancer, direct_access or private_network",
+			[]string{"id", "name", "region", "endpoint_id", "endpoint_type", "private_network_id", "port"}, nil,
+		),
 	}
 }
 
@@ -80,15 +236,38 @@ func NewDatabaseCollector(logger log.Logger, errors *prometheus.CounterVec, clie
 // collected by this Collector.
 func (c *DatabaseCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.Up
+	ch <- c.Info
 	ch <- c.CPUs
 	ch <- c.Memory
 	ch <- c.Connection
 	ch <- c.Disk
+	ch <- c.VolumeSize
+	ch <- c.VolumeInfo
+	ch <- c.ReadReplicas
+	ch <- c.ReadReplicaUp
+	ch <- c.ReadReplicaEndpoints
+	ch <- c.Endpoint
+	ch <- c.Maintenances
+	ch <- c.MaintenanceStart
+	ch <- c.MaintenanceStop
+	ch <- c.MaxConnections
+	ch <- c.EngineVersion
+	ch <- c.EngineEndOfLife
+	ch <- c.UpgradeAvailable
+	ch <- c.LogicalDatabases
+	ch <- c.Users
+	ch <- c.Encryption
+	ch <- c.Snapshots
+	ch <- c.SnapshotsSize
+	ch <- c.SnapshotOldestAge
+	ch <- c.SnapshotNewestAge
+	ch <- c.ACLRules
+	ch <- c.ACLOpenToWorld
 }
 
 // Collect is called by the Prometheus registry when collecting metrics.
 func (c *DatabaseCollector) Collect(ch chan<- prometheus.Metric) {
-	_, cancel := context.WithTimeout(context.Background(), c.timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
 	defer cancel()
 
 	var wg sync.WaitGroup
@@ -96,7 +275,9 @@ func (c *DatabaseCollector) Collect(ch chan<- prometheus.Metric) {
 
 	for _, region := range c.regions {
 		// create a list to hold our databases
-		response, err := c.rdbClient.ListInstances(&rdb.ListInstancesRequest{Region: region}, scw.WithAllPages())
+		response, err := CachedListing(c.cache, "database/"+region.String(), func() (*rdb.ListInstancesResponse, error) {
+			return c.rdbClient.ListInstances(&rdb.ListInstancesRequest{Region: region}, scw.WithAllPages(), scw.WithContext(ctx))
+		})
 
 		if err != nil {
 			c.errors.WithLabelValues("database").Add(1)
@@ -114,32 +295,60 @@ func (c *DatabaseCollector) Collect(ch chan<- prometheus.Metric) {
 			"region", region,
 		)
 
+		engines := c.FetchEngineVersions(ctx, region)
+
 		for _, instance := range response.Instances {
+			if !c.projects.Match(instance.ProjectID) || !c.tags.Match(instance.Tags) {
+				continue
+			}
+
 			wg.Add(1)
 
 			_ = level.Debug(c.logger).Log("msg", fmt.Sprintf("Fetching metrics for database instance : %s", instance.Name))
 
-			go c.FetchMetricsForInstance(&wg, ch, instance)
+			go c.FetchMetricsForInstance(ctx, &wg, ch, instance, engines[instance.Engine])
+		}
+	}
+}
+
+// FetchEngineVersions returns the versions of the database engines available in the region, indexed by their name (e.g. PostgreSQL-14).
+func (c *DatabaseCollector) FetchEngineVersions(ctx context.Context, region scw.Region) map[string]*rdb.EngineVersion {
+	versions := map[string]*rdb.EngineVersion{}
+
+	response, err := c.rdbClient.ListDatabaseEngines(&rdb.ListDatabaseEnginesRequest{Region: region}, scw.WithAllPages(), scw.WithContext(ctx))
+
+	if err != nil {
+		c.errors.WithLabelValues("database").Add(1)
+		_ = level.Warn(c.logger).Log(
+			"msg", "can't fetch the list of database engines",
+			"region", region,
+			"err", err,
+		)
+
+		return versions
+	}
+
+	for _, engine := range response.Engines {
+		for _, version := range engine.Versions {
+			versions[version.Name] = version
 		}
 	}
+
+	return versions
 }
 
-func (c *DatabaseCollector) FetchMetricsForInstance(parentWg *sync.WaitGroup, ch chan<- prometheus.Metric, instance *rdb.Instance) {
+func (c *DatabaseCollector) FetchMetricsForInstance(ctx context.Context, parentWg *sync.WaitGroup, ch chan<- prometheus.Metric, instance *rdb.Instance, engine *rdb.EngineVersion) {
 	defer parentWg.Done()
 
 	labels := []string{
 		instance.ID,
 		instance.Name,
 		instance.Region.String(),
+		instance.ProjectID,
 		instance.Engine,
 		instance.NodeType,
 	}
 
-	// TODO check if it is possible to add database tag as labels
-	// for _, tags := range instance.Tags {
-	//     labels = append(labels, tags)
-	// }
-
 	var active float64
 
 	switch instance.Status {
@@ -180,7 +389,24 @@ func (c *DatabaseCollector) FetchMetricsForInstance(parentWg *sync.WaitGroup, ch
 		labels...,
 	)
 
-	metricResponse, err := c.rdbClient.GetInstanceMetrics(&rdb.GetInstanceMetricsRequest{Region: instance.Region, InstanceID: instance.ID})
+	c.CollectInfo(ch, instance, engine)
+
+	if instance.Volume != nil {
+		ch <- prometheus.MustNewConstMetric(c.VolumeSize, prometheus.GaugeValue, float64(instance.Volume.Size), labels...)
+		ch <- prometheus.MustNewConstMetric(c.VolumeInfo, prometheus.GaugeValue, 1.0, append(append([]string{}, labels...), instance.Volume.Type.String())...)
+	}
+
+	c.CollectReadReplicas(ch, instance, labels)
+	c.CollectEndpoints(ch, instance)
+	c.CollectMaintenances(ch, instance, labels)
+	c.CollectMaxConnections(ch, instance, engine, labels)
+	c.CollectEngineVersion(ch, instance, engine, labels)
+	c.CollectLogicalDatabases(ctx, ch, instance, labels)
+	c.CollectEncryption(ch, instance, labels)
+	c.CollectSnapshots(ctx, ch, instance, labels)
+	c.CollectACLRules(ctx, ch, instance, labels)
+
+	metricResponse, err := c.rdbClient.GetInstanceMetrics(&rdb.GetInstanceMetricsRequest{Region: instance.Region, InstanceID: instance.ID}, scw.WithContext(ctx))
 
 	if err != nil {
 		c.errors.WithLabelValues("database").Add(1)
@@ -214,6 +440,14 @@ func (c *DatabaseCollector) FetchMetricsForInstance(parentWg *sync.WaitGroup, ch
 		case "disk_usage_percent":
 			series = c.Disk
 		default:
+			if c.unmapped {
+				if metric, ok := c.passthrough.Metric("database", timeseries, []string{"id", "name"}, []string{instance.ID, instance.Name}, c.timestamps); ok {
+					ch <- metric
+				}
+
+				continue
+			}
+
 			_ = level.Debug(c.logger).Log(
 				"msg", "unmapped scaleway metric",
 				"err", err,
@@ -243,8 +477,317 @@ func (c *DatabaseCollector) FetchMetricsForInstance(parentWg *sync.WaitGroup, ch
 			return timeseries.Points[i].Timestamp.Before(timeseries.Points[j].Timestamp)
 		})
 
-		value := float64(timeseries.Points[len(timeseries.Points)-1].Value)
+		point := timeseries.Points[len(timeseries.Points)-1]
+
+		ch <- withPointTimestamp(prometheus.MustNewConstMetric(series, prometheus.GaugeValue, float64(point.Value), labelsNode...), point, c.timestamps)
+	}
+}
+
+func (c *DatabaseCollector) CollectReadReplicas(ch chan<- prometheus.Metric, instance *rdb.Instance, labels []string) {
+	ch <- prometheus.MustNewConstMetric(c.ReadReplicas, prometheus.GaugeValue, float64(len(instance.ReadReplicas)), labels...)
+
+	for _, replica := range instance.ReadReplicas {
+		labelsReplica := []string{instance.ID, instance.Name, instance.Region.String(), replica.ID}
+
+		var active float64
+
+		switch replica.Status {
+		case rdb.ReadReplicaStatusReady:
+			active = 1.0
+		case rdb.ReadReplicaStatusProvisioning:
+			active = 0.5
+		case rdb.ReadReplicaStatusInitializing:
+			active = 0.5
+		case rdb.ReadReplicaStatusConfiguring:
+			active = 0.5
+		case rdb.ReadReplicaStatusDeleting:
+			active = 0.5
+		default:
+			active = 0.0
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.ReadReplicaUp, prometheus.GaugeValue, active, append(append([]string{}, labelsReplica...), replica.Status.String())...)
+		ch <- prometheus.MustNewConstMetric(c.ReadReplicaEndpoints, prometheus.GaugeValue, float64(len(replica.Endpoints)), labelsReplica...)
+	}
+}
+
+func (c *DatabaseCollector) CollectEndpoints(ch chan<- prometheus.Metric, instance *rdb.Instance) {
+	for _, endpoint := range instance.Endpoints {
+		var endpointType, privateNetworkID string
+
+		switch {
+		case endpoint.PrivateNetwork != nil:
+			endpointType = "private_network"
+			privateNetworkID = endpoint.PrivateNetwork.PrivateNetworkID
+		case endpoint.LoadBalancer != nil:
+			endpointType = "load_balancer"
+		case endpoint.DirectAccess != nil:
+			endpointType = "direct_access"
+		default:
+			endpointType = "unknown"
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.Endpoint,
+			prometheus.GaugeValue,
+			1.0,
+			instance.ID,
+			instance.Name,
+			instance.Region.String(),
+			endpoint.ID,
+			endpointType,
+			privateNetworkID,
+			fmt.Sprint(endpoint.Port),
+		)
+	}
+}
+
+func (c *DatabaseCollector) CollectMaintenances(ch chan<- prometheus.Metric, instance *rdb.Instance, labels []string) {
+	var pending float64
+
+	// the windows are labeled by reason, only the earliest pending maintenance of each reason is exposed
+	earliest := map[string]*rdb.Maintenance{}
+
+	for _, maintenance := range instance.Maintenances {
+		if maintenance.Status != rdb.MaintenanceStatusPending {
+			continue
+		}
+
+		pending++
+
+		current, ok := earliest[maintenance.Reason]
+
+		if !ok || (maintenance.StartsAt != nil && (current.StartsAt == nil || maintenance.StartsAt.Before(*current.StartsAt))) {
+			earliest[maintenance.Reason] = maintenance
+		}
+	}
+
+	for _, maintenance := range earliest {
+		labelsMaintenance := []string{instance.ID, instance.Name, instance.Region.String(), maintenance.Reason}
+
+		if maintenance.StartsAt != nil {
+			ch <- prometheus.MustNewConstMetric(c.MaintenanceStart, prometheus.GaugeValue, float64(maintenance.StartsAt.Unix()), labelsMaintenance...)
+		}
+
+		if maintenance.StopsAt != nil {
+			ch <- prometheus.MustNewConstMetric(c.MaintenanceStop, prometheus.GaugeValue, float64(maintenance.StopsAt.Unix()), labelsMaintenance...)
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.Maintenances, prometheus.GaugeValue, pending, labels...)
+}
+
+func (c *DatabaseCollector) CollectMaxConnections(ch chan<- prometheus.Metric, instance *rdb.Instance, engine *rdb.EngineVersion, labels []string) {
+	value := ""
+
+	for _, setting := range instance.Settings {
+		if setting.Name == "max_connections" {
+			value = setting.Value
+		}
+	}
+
+	if value == "" && engine != nil {
+		for _, setting := range engine.AvailableSettings {
+			if setting.Name == "max_connections" {
+				value = setting.DefaultValue
+			}
+		}
+	}
+
+	if value == "" {
+		return
+	}
+
+	maxConnections, err := strconv.ParseFloat(value, 64)
+
+	if err != nil {
+		_ = level.Debug(c.logger).Log(
+			"msg", "can't parse the max_connections setting",
+			"err", err,
+			"region", instance.Region,
+			"instanceId", instance.ID,
+			"instanceName", instance.Name,
+		)
+
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.MaxConnections, prometheus.GaugeValue, maxConnections, labels...)
+}
+
+func (c *DatabaseCollector) CollectEngineVersion(ch chan<- prometheus.Metric, instance *rdb.Instance, engine *rdb.EngineVersion, labels []string) {
+	if engine != nil {
+		ch <- prometheus.MustNewConstMetric(c.EngineVersion, prometheus.GaugeValue, 1.0, instance.ID, instance.Name, instance.Region.String(), instance.Engine, engine.Version)
+
+		if engine.EndOfLife != nil {
+			ch <- prometheus.MustNewConstMetric(c.EngineEndOfLife, prometheus.GaugeValue, float64(engine.EndOfLife.Unix()), labels...)
+		}
+	}
+
+	var minor, major float64
+
+	// the upgrades within the same engine version are minor ones
+	for _, version := range instance.UpgradableVersion {
+		if version.Name == instance.Engine {
+			minor = 1.0
+		} else {
+			major = 1.0
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.UpgradeAvailable, prometheus.GaugeValue, minor, append(append([]string{}, labels...), "minor")...)
+	ch <- prometheus.MustNewConstMetric(c.UpgradeAvailable, prometheus.GaugeValue, major, append(append([]string{}, labels...), "major")...)
+}
+
+func (c *DatabaseCollector) CollectLogicalDatabases(ctx context.Context, ch chan<- prometheus.Metric, instance *rdb.Instance, labels []string) {
+	databases, err := c.rdbClient.ListDatabases(&rdb.ListDatabasesRequest{Region: instance.Region, InstanceID: instance.ID}, scw.WithAllPages(), scw.WithContext(ctx))
+
+	if err != nil {
+		c.errors.WithLabelValues("database").Add(1)
+		_ = level.Warn(c.logger).Log(
+			"msg", "can't fetch the list of logical databases",
+			"err", err,
+			"region", instance.Region,
+			"instanceId", instance.ID,
+			"instanceName", instance.Name,
+		)
+	} else {
+		ch <- prometheus.MustNewConstMetric(c.LogicalDatabases, prometheus.GaugeValue, float64(len(databases.Databases)), labels...)
+	}
+
+	users, err := c.rdbClient.ListUsers(&rdb.ListUsersRequest{Region: instance.Region, InstanceID: instance.ID}, scw.WithAllPages(), scw.WithContext(ctx))
+
+	if err != nil {
+		c.errors.WithLabelValues("database").Add(1)
+		_ = level.Warn(c.logger).Log(
+			"msg", "can't fetch the list of users",
+			"err", err,
+			"region", instance.Region,
+			"instanceId", instance.ID,
+			"instanceName", instance.Name,
+		)
+
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.Users, prometheus.GaugeValue, float64(len(users.Users)), labels...)
+}
+
+func (c *DatabaseCollector) CollectEncryption(ch chan<- prometheus.Metric, instance *rdb.Instance, labels []string) {
+	var enabled float64
+
+	if instance.Encryption != nil && instance.Encryption.Enabled {
+		enabled = 1.0
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.Encryption, prometheus.GaugeValue, enabled, labels...)
+}
+
+func (c *DatabaseCollector) CollectSnapshots(ctx context.Context, ch chan<- prometheus.Metric, instance *rdb.Instance, labels []string) {
+	response, err := c.rdbClient.ListSnapshots(&rdb.ListSnapshotsRequest{Region: instance.Region, InstanceID: &instance.ID}, scw.WithAllPages(), scw.WithContext(ctx))
+
+	if err != nil {
+		c.errors.WithLabelValues("database").Add(1)
+		_ = level.Warn(c.logger).Log(
+			"msg", "can't fetch the list of snapshots",
+			"err", err,
+			"region", instance.Region,
+			"instanceId", instance.ID,
+			"instanceName", instance.Name,
+		)
+
+		return
+	}
+
+	var size float64
 
-		ch <- prometheus.MustNewConstMetric(series, prometheus.GaugeValue, value, labelsNode...)
+	var oldest, newest *time.Time
+
+	for _, snapshot := range response.Snapshots {
+		if snapshot.Size != nil {
+			size += float64(*snapshot.Size)
+		}
+
+		if snapshot.CreatedAt == nil {
+			continue
+		}
+
+		if oldest == nil || snapshot.CreatedAt.Before(*oldest) {
+			oldest = snapshot.CreatedAt
+		}
+
+		if newest == nil || snapshot.CreatedAt.After(*newest) {
+			newest = snapshot.CreatedAt
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.Snapshots, prometheus.GaugeValue, float64(len(response.Snapshots)), labels...)
+	ch <- prometheus.MustNewConstMetric(c.SnapshotsSize, prometheus.GaugeValue, size, labels...)
+
+	if oldest != nil {
+		ch <- prometheus.MustNewConstMetric(c.SnapshotOldestAge, prometheus.GaugeValue, time.Since(*oldest).Seconds(), labels...)
+		ch <- prometheus.MustNewConstMetric(c.SnapshotNewestAge, prometheus.GaugeValue, time.Since(*newest).Seconds(), labels...)
+	}
+}
+
+func (c *DatabaseCollector) CollectInfo(ch chan<- prometheus.Metric, instance *rdb.Instance, engine *rdb.EngineVersion) {
+	var version, backupDisabled, backupFrequency, backupRetention string
+
+	if engine != nil {
+		version = engine.Version
+	}
+
+	if instance.BackupSchedule != nil {
+		backupDisabled = strconv.FormatBool(instance.BackupSchedule.Disabled)
+		backupFrequency = fmt.Sprint(instance.BackupSchedule.Frequency)
+		backupRetention = fmt.Sprint(instance.BackupSchedule.Retention)
 	}
+
+	labels := []string{
+		instance.ID,
+		instance.Name,
+		instance.Region.String(),
+		instance.ProjectID,
+		instance.Engine,
+		version,
+		instance.NodeType,
+		strconv.FormatBool(instance.IsHaCluster),
+		backupDisabled,
+		backupFrequency,
+		backupRetention,
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.Info, prometheus.GaugeValue, 1.0, append(labels, c.tagLabels.Values(instance.Tags)...)...)
+}
+
+func (c *DatabaseCollector) CollectACLRules(ctx context.Context, ch chan<- prometheus.Metric, instance *rdb.Instance, labels []string) {
+	response, err := c.rdbClient.ListInstanceACLRules(&rdb.ListInstanceACLRulesRequest{Region: instance.Region, InstanceID: instance.ID}, scw.WithAllPages(), scw.WithContext(ctx))
+
+	if err != nil {
+		c.errors.WithLabelValues("database").Add(1)
+		_ = level.Warn(c.logger).Log(
+			"msg", "can't fetch the ACL rules of the instance",
+			"err", err,
+			"region", instance.Region,
+			"instanceId", instance.ID,
+			"instanceName", instance.Name,
+		)
+
+		return
+	}
+
+	var openToWorld float64
+
+	for _, rule := range response.Rules {
+		if rule.Action == rdb.ACLRuleActionDeny || rule.IP.IP == nil {
+			continue
+		}
+
+		if ones, _ := rule.IP.Mask.Size(); ones == 0 {
+			openToWorld = 1.0
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.ACLRules, prometheus.GaugeValue, float64(len(response.Rules)), labels...)
+	ch <- prometheus.MustNewConstMetric(c.ACLOpenToWorld, prometheus.GaugeValue, openToWorld, labels...)
 }
@@ -0,0 +1,80 @@
+package collector
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// TestDescribe checks that the descriptors of every collector are valid and have a help text.
+func TestDescribe(t *testing.T) {
+	client, err := scw.NewClient(scw.WithAuth("SCWXXXXXXXXXXXXXXXXX", "11111111-1111-1111-1111-111111111111"))
+	if err != nil {
+		t.Fatalf("can't create the Scaleway client: %s", err)
+	}
+
+	logger := log.NewNopLogger()
+	errors := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "scaleway_errors_total", Help: "The total number of errors per collector"}, []string{"collector"})
+	timeout := time.Second
+	zones := []scw.Zone{scw.ZoneFrPar1}
+	regions := []scw.Region{scw.RegionFrPar}
+	organizationIDs := []string{"11111111-1111-1111-1111-111111111111"}
+	cache := NewListingCache(0)
+
+	tagLabels, err := ParseTagLabels([]string{"env=label:environment"})
+	if err != nil {
+		t.Fatalf("can't parse the tag labels: %s", err)
+	}
+
+	collectors := map[string]prometheus.Collector{
+		"applesilicon":   NewAppleSiliconCollector(logger, errors, client, timeout, zones, nil),
+		"billing":        NewBillingCollector(logger, errors, client, timeout, organizationIDs, BillingOptions{}),
+		"block":          NewBlockCollector(logger, errors, client, timeout, zones, nil, TagFilter{}, tagLabels),
+		"bucket":         NewBucketCollector(logger, errors, client, timeout, regions, cache, BucketOptions{TagLabels: []string{"team"}, MappedTagLabels: tagLabels}),
+		"database":       NewDatabaseCollector(logger, errors, client, timeout, regions, nil, TagFilter{}, cache, false, false, tagLabels),
+		"documentdb":     NewDocumentDBCollector(logger, errors, client, timeout, regions, nil, TagFilter{}, false, tagLabels),
+		"domain":         NewDomainCollector(logger, errors, client, timeout, nil),
+		"edgeservices":   NewEdgeServicesCollector(logger, errors, client, timeout, nil),
+		"exporter":       NewExporterCollector(logger, "", "", "", "", time.Now()),
+		"iam":            NewIAMCollector(logger, errors, client, timeout, organizationIDs),
+		"inference":      NewInferenceCollector(logger, errors, client, timeout, regions, nil, TagFilter{}, tagLabels),
+		"instance":       NewInstanceCollector(logger, errors, client, timeout, zones, nil, TagFilter{}, cache, tagLabels),
+		"ipam":           NewIPAMCollector(logger, errors, client, timeout, regions, nil, TagFilter{}, tagLabels),
+		"kubernetes":     NewKubernetesCollector(logger, errors, client, timeout, regions, nil, TagFilter{}, cache, tagLabels),
+		"loadbalancer":   NewLoadBalancerCollector(logger, errors, client, timeout, zones, nil, TagFilter{}, cache, false, false, LoadBalancerOptions{TagLabels: tagLabels}),
+		"mnq":            NewMNQCollector(logger, errors, client, timeout, regions, nil, MNQOptions{}),
+		"placementgroup": NewPlacementGroupCollector(logger, errors, client, timeout, zones, nil, TagFilter{}, tagLabels),
+		"project":        NewProjectCollector(logger, errors, client, timeout, regions, zones, nil, organizationIDs),
+		"quota":          NewQuotaCollector(logger, errors, client, timeout, zones, organizationIDs),
+		"redis":          NewRedisCollector(logger, errors, client, timeout, zones, nil, TagFilter{}, cache, false, false, tagLabels),
+		"registry":       NewRegistryCollector(logger, errors, client, timeout, regions, nil),
+		"securitygroup":  NewSecurityGroupCollector(logger, errors, client, timeout, zones, nil, TagFilter{}, tagLabels),
+		"tem":            NewTEMCollector(logger, errors, client, timeout, regions, nil),
+		"vpc":            NewVPCCollector(logger, errors, client, timeout, regions, nil, TagFilter{}, tagLabels),
+	}
+
+	for name, collector := range collectors {
+		// the registry rejects the invalid and duplicated descriptors
+		if errRegister := prometheus.NewPedanticRegistry().Register(collector); errRegister != nil {
+			t.Errorf("the %s collector has invalid descriptors: %s", name, errRegister)
+		}
+
+		ch := make(chan *prometheus.Desc)
+
+		go func() {
+			collector.Describe(ch)
+			close(ch)
+		}()
+
+		for desc := range ch {
+			// the help text is only reachable through the description of the descriptor
+			if strings.Contains(desc.String(), `help: ""`) {
+				t.Errorf("the %s collector has a descriptor without help: %s", name, desc)
+			}
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	documentdb "github.com/scaleway/scaleway-sdk-go/api/documentdb/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// DocumentDBCollector collects metrics about all Document DB instances.
+type DocumentDBCollector struct {
+	logger           log.Logger
+	errors           *prometheus.CounterVec
+	client           *scw.Client
+	documentDBClient *documentdb.API
+	timeout          time.Duration
+	regions          []scw.Region
+	projects         ProjectFilter
+	tags             TagFilter
+	timestamps       bool
+	tagLabels        TagLabels
+
+	Up         *prometheus.Desc
+	CPUs       *prometheus.Desc
+	Memory     *prometheus.Desc
+	Connection *prometheus.Desc
+	Disk       *prometheus.Desc
+}
+
+// NewDocumentDBCollector returns a new DocumentDBCollector.
+func NewDocumentDBCollector(logger log.Logger, errors *prometheus.CounterVec, client *scw.Client, timeout time.Duration, regions []scw.Region, projects ProjectFilter, tags TagFilter, timestamps bool, tagLabels TagLabels) *DocumentDBCollector {
+	errors.WithLabelValues("documentdb").Add(0)
+
+	_ = level.Info(logger).Log("msg", "Document DB collector enabled")
+
+	labels := []string{"id", "name", "region", "project_id", "engine", "type"}
+
+	labelsNode := []string{"id", "name", "node"}
+
+	return &DocumentDBCollector{
+		logger:           logger,
+		errors:           errors,
+		client:           client,
+		documentDBClient: documentdb.NewAPI(client),
+		timeout:          timeout,
+		regions:          regions,
+		projects:         projects,
+		tags:             tags,
+		timestamps:       timestamps,
+		tagLabels:        tagLabels,
+
+		Up: prometheus.NewDesc(
+			"scaleway_documentdb_up",
+			"If 1 the document database is up and running, 0.5 in autohealing, 0 otherwise",
+			append(append([]string{}, labels...), tagLabels.Names()...), nil,
+		),
+		CPUs: prometheus.NewDesc(
+			"scaleway_documentdb_cpu_usage_percent",
+			"Document database's CPUs percentage usage",
+			labelsNode, nil,
+		),
+		Memory: prometheus.NewDesc(
+			"scaleway_documentdb_memory_usage_percent",
+			"Document database's memory percentage usage",
+			labelsNode, nil,
+		),
+		Connection: prometheus.NewDesc(
+			"scaleway_documentdb_total_connections",
+			"Document database's connection count",
+			labelsNode, nil,
+		),
+		Disk: prometheus.NewDesc(
+			"scaleway_documentdb_disk_usage_percent",
+			"Document database's disk percentage usage",
+			labelsNode, nil,
+		),
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+// collected by this Collector.
+func (c *DocumentDBCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Up
+	ch <- c.CPUs
+	ch <- c.Memory
+	ch <- c.Connection
+	ch <- c.Disk
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *DocumentDBCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for _, region := range c.regions {
+		response, err := c.documentDBClient.ListInstances(&documentdb.ListInstancesRequest{Region: region}, scw.WithAllPages(), scw.WithContext(ctx))
+
+		if err != nil {
+			var responseError *scw.ResponseError
+
+			switch {
+			case errors.As(err, &responseError) && (responseError.StatusCode == http.StatusNotImplemented || responseError.StatusCode == http.StatusNotFound):
+				_ = level.Debug(c.logger).Log("msg", "Document DB is not supported in this region", "region", region)
+			default:
+				c.errors.WithLabelValues("documentdb").Add(1)
+				_ = level.Warn(c.logger).Log("msg", "can't fetch the list of document databases", "region", region, "err", err)
+			}
+
+			continue
+		}
+
+		_ = level.Debug(c.logger).Log(
+			"msg", fmt.Sprintf("found %d document database instances", len(response.Instances)),
+			"region", region,
+		)
+
+		for _, instance := range response.Instances {
+			if !c.projects.Match(instance.ProjectID) || !c.tags.Match(instance.Tags) {
+				continue
+			}
+
+			wg.Add(1)
+
+			_ = level.Debug(c.logger).Log("msg", fmt.Sprintf("Fetching metrics for document database instance : %s", instance.Name))
+
+			go c.FetchMetricsForInstance(ctx, &wg, ch, instance)
+		}
+	}
+}
+
+func (c *DocumentDBCollector) FetchMetricsForInstance(ctx context.Context, parentWg *sync.WaitGroup, ch chan<- prometheus.Metric, instance *documentdb.Instance) {
+	defer parentWg.Done()
+
+	labels := []string{
+		instance.ID,
+		instance.Name,
+		instance.Region.String(),
+		instance.ProjectID,
+		instance.Engine,
+		instance.NodeType,
+	}
+
+	var active float64
+
+	switch instance.Status {
+	case documentdb.InstanceStatusReady:
+		active = 1.0
+	case documentdb.InstanceStatusBackuping:
+		active = 1.0
+	case documentdb.InstanceStatusAutohealing:
+		active = 0.5
+	case documentdb.InstanceStatusProvisioning:
+		active = 0.5
+	case documentdb.InstanceStatusConfiguring:
+		active = 0.5
+	case documentdb.InstanceStatusDeleting:
+		active = 0.5
+	case documentdb.InstanceStatusSnapshotting:
+		active = 0.5
+	case documentdb.InstanceStatusRestarting:
+		active = 0.5
+	case documentdb.InstanceStatusUnknown:
+		active = 0.0
+	case documentdb.InstanceStatusError:
+		active = 0.0
+	case documentdb.InstanceStatusLocked:
+		active = 0.0
+	case documentdb.InstanceStatusInitializing:
+		active = 0.0
+	case documentdb.InstanceStatusDiskFull:
+		active = 0.0
+	default:
+		active = 0.0
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.Up, prometheus.GaugeValue, active, append(labels, c.tagLabels.Values(instance.Tags)...)...)
+
+	metricResponse, err := c.documentDBClient.GetInstanceMetrics(&documentdb.GetInstanceMetricsRequest{Region: instance.Region, InstanceID: instance.ID}, scw.WithContext(ctx))
+
+	if err != nil {
+		c.errors.WithLabelValues("documentdb").Add(1)
+		_ = level.Warn(c.logger).Log(
+			"msg", "can't fetch the metric for the document database instance",
+			"err", err,
+			"region", instance.Region,
+			"instanceId", instance.ID,
+			"instanceName", instance.Name,
+		)
+
+		return
+	}
+
+	for _, timeseries := range metricResponse.Timeseries {
+		labelsNode := []string{
+			instance.ID,
+			instance.Name,
+			timeseries.Metadata["node"],
+		}
+
+		var series *prometheus.Desc
+
+		switch timeseries.Name {
+		case "cpu_usage_percent":
+			series = c.CPUs
+		case "mem_usage_percent":
+			series = c.Memory
+		case "total_connections":
+			series = c.Connection
+		case "disk_usage_percent":
+			series = c.Disk
+		default:
+			_ = level.Debug(c.logger).Log(
+				"msg", "unmapped scaleway metric",
+				"region", instance.Region,
+				"instanceId", instance.ID,
+				"instanceName", instance.Name,
+				"scwMetric", timeseries.Name,
+			)
+			continue
+		}
+
+		if len(timeseries.Points) == 0 {
+			c.errors.WithLabelValues("documentdb").Add(1)
+			_ = level.Warn(c.logger).Log(
+				"msg", "no data were returned for the metric",
+				"instanceName", instance.Name,
+				"instanceId", instance.ID,
+				"metric", timeseries.Name,
+				"region", instance.Region,
+			)
+
+			continue
+		}
+
+		sort.Slice(timeseries.Points, func(i, j int) bool {
+			return timeseries.Points[i].Timestamp.Before(timeseries.Points[j].Timestamp)
+		})
+
+		point := timeseries.Points[len(timeseries.Points)-1]
+
+		ch <- withPointTimestamp(prometheus.MustNewConstMetric(series, prometheus.GaugeValue, float64(point.Value), labelsNode...), point, c.timestamps)
+	}
+}
@@ -0,0 +1,167 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	domain "github.com/scaleway/scaleway-sdk-go/api/domain/v2beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// DomainCollector collects metrics about all DNS zones.
+type DomainCollector struct {
+	logger       log.Logger
+	errors       *prometheus.CounterVec
+	client       *scw.Client
+	domainClient *domain.API
+	timeout      time.Duration
+	projects     ProjectFilter
+
+	ZoneUp               *prometheus.Desc
+	ZoneRecords          *prometheus.Desc
+	ZoneNameserversValid *prometheus.Desc
+}
+
+// NewDomainCollector returns a new DomainCollector.
+func NewDomainCollector(logger log.Logger, errors *prometheus.CounterVec, client *scw.Client, timeout time.Duration, projects ProjectFilter) *DomainCollector {
+	errors.WithLabelValues("domain").Add(0)
+
+	_ = level.Info(logger).Log("msg", "Domain collector enabled")
+
+	labels := []string{"zone", "domain", "project_id"}
+
+	return &DomainCollector{
+		logger:       logger,
+		errors:       errors,
+		client:       client,
+		domainClient: domain.NewAPI(client),
+		timeout:      timeout,
+		projects:     projects,
+
+		ZoneUp: prometheus.NewDesc(
+			"scaleway_domain_zone_up",
+			"If 1 the DNS zone is active, 0.5 when pending, 0 otherwise",
+			append(append([]string{}, labels...), "status"), nil,
+		),
+		ZoneRecords: prometheus.NewDesc(
+			"scaleway_domain_zone_records",
+			"The number of records of the DNS zone",
+			labels, nil,
+		),
+		ZoneNameserversValid: prometheus.NewDesc(
+			"scaleway_domain_zone_nameservers_valid",
+			"If 1 the DNS zone is delegated to the Scaleway nameservers (or is a secondary zone), 0 otherwise",
+			append(append([]string{}, labels...), "nameservers"), nil,
+		),
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+// collected by this Collector.
+func (c *DomainCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.ZoneUp
+	ch <- c.ZoneRecords
+	ch <- c.ZoneNameserversValid
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *DomainCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	response, err := c.domainClient.ListDNSZones(&domain.ListDNSZonesRequest{}, scw.WithAllPages(), scw.WithContext(ctx))
+
+	if err != nil {
+		c.errors.WithLabelValues("domain").Add(1)
+		_ = level.Warn(c.logger).Log("msg", "can't fetch the list of DNS zones", "err", err)
+
+		return
+	}
+
+	_ = level.Debug(c.logger).Log("msg", fmt.Sprintf("found %d DNS zones", len(response.DNSZones)))
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for _, zone := range response.DNSZones {
+		if !c.projects.Match(zone.ProjectID) {
+			continue
+		}
+
+		wg.Add(1)
+
+		go c.FetchZoneMetrics(ctx, &wg, ch, zone)
+	}
+}
+
+func (c *DomainCollector) FetchZoneMetrics(ctx context.Context, parentWg *sync.WaitGroup, ch chan<- prometheus.Metric, zone *domain.DNSZone) {
+	defer parentWg.Done()
+
+	name := zone.Domain
+
+	if zone.Subdomain != "" {
+		name = zone.Subdomain + "." + zone.Domain
+	}
+
+	labels := []string{name, zone.Domain, zone.ProjectID}
+
+	var active float64
+
+	switch zone.Status {
+	case domain.DNSZoneStatusActive:
+		active = 1.0
+	case domain.DNSZoneStatusPending:
+		active = 0.5
+	case domain.DNSZoneStatusError:
+		active = 0.0
+	case domain.DNSZoneStatusLocked:
+		active = 0.0
+	case domain.DNSZoneStatusUnknown:
+		active = 0.0
+	default:
+		active = 0.0
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.ZoneUp, prometheus.GaugeValue, active, append(append([]string{}, labels...), zone.Status.String())...)
+
+	nameservers := append([]string{}, zone.Ns...)
+	nameserversDefault := append([]string{}, zone.NsDefault...)
+
+	sort.Strings(nameservers)
+	sort.Strings(nameserversDefault)
+
+	var valid float64
+
+	if len(zone.NsMaster) > 0 || strings.Join(nameservers, ",") == strings.Join(nameserversDefault, ",") {
+		valid = 1.0
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.ZoneNameserversValid,
+		prometheus.GaugeValue,
+		valid,
+		append(append([]string{}, labels...), strings.Join(nameservers, ","))...,
+	)
+
+	records, err := c.domainClient.ListDNSZoneRecords(&domain.ListDNSZoneRecordsRequest{DNSZone: name}, scw.WithAllPages(), scw.WithContext(ctx))
+
+	if err != nil {
+		c.errors.WithLabelValues("domain").Add(1)
+		_ = level.Warn(c.logger).Log(
+			"msg", "can't fetch the records of the DNS zone",
+			"zone", name,
+			"err", err,
+		)
+
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.ZoneRecords, prometheus.GaugeValue, float64(records.TotalCount), labels...)
+}
@@ -0,0 +1,159 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	edge_services "github.com/scaleway/scaleway-sdk-go/api/edge_services/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// EdgeServicesCollector collects metrics about all Edge Services pipelines.
+type EdgeServicesCollector struct {
+	logger             log.Logger
+	errors             *prometheus.CounterVec
+	edgeServicesClient *edge_services.API
+	timeout            time.Duration
+	projects           ProjectFilter
+
+	PipelineUp          *prometheus.Desc
+	PipelineCacheStages *prometheus.Desc
+	CacheStageTTL       *prometheus.Desc
+}
+
+// NewEdgeServicesCollector returns a new EdgeServicesCollector.
+func NewEdgeServicesCollector(logger log.Logger, errors *prometheus.CounterVec, client *scw.Client, timeout time.Duration, projects ProjectFilter) *EdgeServicesCollector {
+	errors.WithLabelValues("edgeservices").Add(0)
+
+	_ = level.Info(logger).Log("msg", "Edge Services collector enabled")
+
+	labels := []string{"id", "name", "project_id"}
+
+	return &EdgeServicesCollector{
+		logger:             logger,
+		errors:             errors,
+		edgeServicesClient: edge_services.NewAPI(client),
+		timeout:            timeout,
+		projects:           projects,
+
+		PipelineUp: prometheus.NewDesc(
+			"scaleway_edgeservices_pipeline_up",
+			"If 1 the pipeline is ready, 0.5 when pending, 0 otherwise",
+			append(append([]string{}, labels...), "status"), nil,
+		),
+		PipelineCacheStages: prometheus.NewDesc(
+			"scaleway_edgeservices_pipeline_cache_stages",
+			"The number of cache stages of the pipeline",
+			labels, nil,
+		),
+		CacheStageTTL: prometheus.NewDesc(
+			"scaleway_edgeservices_cache_stage_fallback_ttl_seconds",
+			"The TTL applied to the cached objects when the origin does not send any cache header",
+			[]string{"pipeline_id", "pipeline_name", "id"}, nil,
+		),
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+// collected by this Collector.
+func (c *EdgeServicesCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.PipelineUp
+	ch <- c.PipelineCacheStages
+	ch <- c.CacheStageTTL
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *EdgeServicesCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	response, err := c.edgeServicesClient.ListPipelines(&edge_services.ListPipelinesRequest{}, scw.WithAllPages(), scw.WithContext(ctx))
+
+	if err != nil {
+		var responseError *scw.ResponseError
+
+		switch {
+		case errors.As(err, &responseError) && (responseError.StatusCode == http.StatusNotImplemented || responseError.StatusCode == http.StatusNotFound):
+			_ = level.Debug(c.logger).Log("msg", "Edge Services is not supported")
+		default:
+			c.errors.WithLabelValues("edgeservices").Add(1)
+			_ = level.Warn(c.logger).Log("msg", "can't fetch the list of Edge Services pipelines", "err", err)
+		}
+
+		return
+	}
+
+	_ = level.Debug(c.logger).Log("msg", fmt.Sprintf("found %d Edge Services pipelines", len(response.Pipelines)))
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for _, pipeline := range response.Pipelines {
+		if !c.projects.Match(pipeline.ProjectID) {
+			continue
+		}
+
+		wg.Add(1)
+
+		go c.FetchPipelineMetrics(ctx, &wg, ch, pipeline)
+	}
+}
+
+func (c *EdgeServicesCollector) FetchPipelineMetrics(ctx context.Context, parentWg *sync.WaitGroup, ch chan<- prometheus.Metric, pipeline *edge_services.Pipeline) {
+	defer parentWg.Done()
+
+	labels := []string{pipeline.ID, pipeline.Name, pipeline.ProjectID}
+
+	var active float64
+
+	switch pipeline.Status {
+	case edge_services.PipelineStatusReady:
+		active = 1.0
+	case edge_services.PipelineStatusPending:
+		active = 0.5
+	case edge_services.PipelineStatusError:
+		active = 0.0
+	default:
+		active = 0.0
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.PipelineUp, prometheus.GaugeValue, active, append(append([]string{}, labels...), pipeline.Status.String())...)
+
+	stages, err := c.edgeServicesClient.ListCacheStages(&edge_services.ListCacheStagesRequest{PipelineID: scw.StringPtr(pipeline.ID)}, scw.WithAllPages(), scw.WithContext(ctx))
+
+	if err != nil {
+		c.errors.WithLabelValues("edgeservices").Add(1)
+		_ = level.Warn(c.logger).Log(
+			"msg", "can't fetch the list of cache stages for the Edge Services pipeline",
+			"pipelineId", pipeline.ID,
+			"pipelineName", pipeline.Name,
+			"err", err,
+		)
+
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.PipelineCacheStages, prometheus.GaugeValue, float64(len(stages.Stages)), labels...)
+
+	for _, stage := range stages.Stages {
+		if stage.FallbackTTL == nil {
+			continue
+		}
+
+		ttl := stage.FallbackTTL.ToTimeDuration()
+
+		ch <- prometheus.MustNewConstMetric(
+			c.CacheStageTTL,
+			prometheus.GaugeValue,
+			ttl.Seconds(),
+			pipeline.ID, pipeline.Name, stage.ID,
+		)
+	}
+}
@@ -0,0 +1,54 @@
+package collector
+
+// ProjectFilter restricts the collected resources to some projects, the resources of every project are collected when it is empty.
+type ProjectFilter []string
+
+// Match returns true if the resources of the project are collected.
+func (f ProjectFilter) Match(projectID string) bool {
+	if len(f) == 0 {
+		return true
+	}
+
+	for _, id := range f {
+		if id == projectID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TagFilter restricts the collected resources by their Scaleway tags.
+type TagFilter struct {
+	// Include keeps only the resources carrying at least one of these tags, when not empty.
+	Include []string
+	// Exclude drops the resources carrying any of these tags.
+	Exclude []string
+}
+
+// Match returns true if a resource carrying the tags is collected.
+func (f TagFilter) Match(tags []string) bool {
+	carried := make(map[string]bool, len(tags))
+
+	for _, tag := range tags {
+		carried[tag] = true
+	}
+
+	for _, tag := range f.Exclude {
+		if carried[tag] {
+			return false
+		}
+	}
+
+	if len(f.Include) == 0 {
+		return true
+	}
+
+	for _, tag := range f.Include {
+		if carried[tag] {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,332 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// IAMCollector collects metrics about the IAM principals and policies of the organizations.
+type IAMCollector struct {
+	logger          log.Logger
+	errors          *prometheus.CounterVec
+	client          *scw.Client
+	iamClient       *iam.API
+	timeout         time.Duration
+	organizationIDs []string
+
+	Users              *prometheus.Desc
+	Applications       *prometheus.Desc
+	Groups             *prometheus.Desc
+	APIKeys            *prometheus.Desc
+	Policies           *prometheus.Desc
+	UserInfo           *prometheus.Desc
+	ApplicationAPIKeys *prometheus.Desc
+	GroupMembers       *prometheus.Desc
+	PolicyRules        *prometheus.Desc
+	APIKeyAge          *prometheus.Desc
+	APIKeyExpiresAt    *prometheus.Desc
+	SSHKeys            *prometheus.Desc
+	SSHKeyAge          *prometheus.Desc
+}
+
+// NewIAMCollector returns a new IAMCollector.
+func NewIAMCollector(logger log.Logger, errors *prometheus.CounterVec, client *scw.Client, timeout time.Duration, organizationIDs []string) *IAMCollector {
+	errors.WithLabelValues("iam").Add(0)
+
+	_ = level.Info(logger).Log("msg", "IAM collector enabled")
+
+	labels := []string{"organization_id"}
+
+	labelsAPIKey := []string{"organization_id", "access_key", "description", "bearer_type", "bearer_id", "current"}
+
+	return &IAMCollector{
+		logger:          logger,
+		errors:          errors,
+		client:          client,
+		iamClient:       iam.NewAPI(client),
+		timeout:         timeout,
+		organizationIDs: organizationIDs,
+
+		Users: prometheus.NewDesc(
+			"scaleway_iam_users",
+			"The number of users of the organization",
+			labels, nil,
+		),
+		Applications: prometheus.NewDesc(
+			"scaleway_iam_applications",
+			"The number of applications of the organization",
+			labels, nil,
+		),
+		Groups: prometheus.NewDesc(
+			"scaleway_iam_groups",
+			"The number of groups of the organization",
+			labels, nil,
+		),
+		APIKeys: prometheus.NewDesc(
+			"scaleway_iam_api_keys",
+			"The number of API keys of the organization",
+			labels, nil,
+		),
+		Policies: prometheus.NewDesc(
+			"scaleway_iam_policies",
+			"The number of policies of the organization",
+			labels, nil,
+		),
+		UserInfo: prometheus.NewDesc(
+			"scaleway_iam_user_info",
+			"A metric with a constant '1' value labeled by the user information",
+			[]string{"organization_id", "id", "email", "type", "status", "two_factor_enabled"}, nil,
+		),
+		ApplicationAPIKeys: prometheus.NewDesc(
+			"scaleway_iam_application_api_keys",
+			"The number of API keys of the application",
+			[]string{"organization_id", "id", "name"}, nil,
+		),
+		GroupMembers: prometheus.NewDesc(
+			"scaleway_iam_group_members",
+			"The number of users and applications member of the group",
+			[]string{"organization_id", "id", "name"}, nil,
+		),
+		PolicyRules: prometheus.NewDesc(
+			"scaleway_iam_policy_rules",
+			"The number of rules of the policy",
+			[]string{"organization_id", "id", "name"}, nil,
+		),
+		APIKeyAge: prometheus.NewDesc(
+			"scaleway_iam_api_key_age_seconds",
+			"The number of seconds since the API key was created",
+			labelsAPIKey, nil,
+		),
+		APIKeyExpiresAt: prometheus.NewDesc(
+			"scaleway_iam_api_key_expires_at_timestamp_seconds",
+			"Timestamp of the expiration of the API key, only for the keys with an expiration date",
+			labelsAPIKey, nil,
+		),
+		SSHKeys: prometheus.NewDesc(
+			"scaleway_ssh_keys",
+			"The number of SSH keys of the organization",
+			labels, nil,
+		),
+		SSHKeyAge: prometheus.NewDesc(
+			"scaleway_ssh_key_age_seconds",
+			"The number of seconds since the SSH key was created",
+			[]string{"organization_id", "project_id", "id", "name", "fingerprint", "disabled"}, nil,
+		),
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+// collected by this Collector.
+func (c *IAMCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Users
+	ch <- c.Applications
+	ch <- c.Groups
+	ch <- c.APIKeys
+	ch <- c.Policies
+	ch <- c.UserInfo
+	ch <- c.ApplicationAPIKeys
+	ch <- c.GroupMembers
+	ch <- c.PolicyRules
+	ch <- c.APIKeyAge
+	ch <- c.APIKeyExpiresAt
+	ch <- c.SSHKeys
+	ch <- c.SSHKeyAge
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *IAMCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	for _, organizationID := range c.organizationIDs {
+		c.CollectUsers(ctx, ch, organizationID)
+		c.CollectApplications(ctx, ch, organizationID)
+		c.CollectGroups(ctx, ch, organizationID)
+		c.CollectAPIKeys(ctx, ch, organizationID)
+		c.CollectPolicies(ctx, ch, organizationID)
+		c.CollectSSHKeys(ctx, ch, organizationID)
+	}
+}
+
+func (c *IAMCollector) CollectUsers(ctx context.Context, ch chan<- prometheus.Metric, organizationID string) {
+	response, err := c.iamClient.ListUsers(&iam.ListUsersRequest{OrganizationID: &organizationID}, scw.WithAllPages(), scw.WithContext(ctx))
+
+	if err != nil {
+		c.errors.WithLabelValues("iam").Add(1)
+		_ = level.Warn(c.logger).Log("msg", "can't fetch the list of users, perhaps you are missing the 'IAMReadOnly' permission", "err", err, "organization_id", organizationID)
+
+		return
+	}
+
+	_ = level.Debug(c.logger).Log("msg", fmt.Sprintf("found %d users", len(response.Users)), "organization_id", organizationID)
+
+	ch <- prometheus.MustNewConstMetric(c.Users, prometheus.GaugeValue, float64(len(response.Users)), organizationID)
+
+	for _, user := range response.Users {
+		ch <- prometheus.MustNewConstMetric(
+			c.UserInfo,
+			prometheus.GaugeValue,
+			1.0,
+			organizationID,
+			user.ID,
+			user.Email,
+			user.Type.String(),
+			user.Status.String(),
+			fmt.Sprint(user.TwoFactorEnabled),
+		)
+	}
+}
+
+func (c *IAMCollector) CollectApplications(ctx context.Context, ch chan<- prometheus.Metric, organizationID string) {
+	response, err := c.iamClient.ListApplications(&iam.ListApplicationsRequest{OrganizationID: organizationID}, scw.WithAllPages(), scw.WithContext(ctx))
+
+	if err != nil {
+		c.errors.WithLabelValues("iam").Add(1)
+		_ = level.Warn(c.logger).Log("msg", "can't fetch the list of applications", "err", err, "organization_id", organizationID)
+
+		return
+	}
+
+	_ = level.Debug(c.logger).Log("msg", fmt.Sprintf("found %d applications", len(response.Applications)), "organization_id", organizationID)
+
+	ch <- prometheus.MustNewConstMetric(c.Applications, prometheus.GaugeValue, float64(len(response.Applications)), organizationID)
+
+	for _, application := range response.Applications {
+		ch <- prometheus.MustNewConstMetric(
+			c.ApplicationAPIKeys,
+			prometheus.GaugeValue,
+			float64(application.NbAPIKeys),
+			organizationID, application.ID, application.Name,
+		)
+	}
+}
+
+func (c *IAMCollector) CollectGroups(ctx context.Context, ch chan<- prometheus.Metric, organizationID string) {
+	response, err := c.iamClient.ListGroups(&iam.ListGroupsRequest{OrganizationID: organizationID}, scw.WithAllPages(), scw.WithContext(ctx))
+
+	if err != nil {
+		c.errors.WithLabelValues("iam").Add(1)
+		_ = level.Warn(c.logger).Log("msg", "can't fetch the list of groups", "err", err, "organization_id", organizationID)
+
+		return
+	}
+
+	_ = level.Debug(c.logger).Log("msg", fmt.Sprintf("found %d groups", len(response.Groups)), "organization_id", organizationID)
+
+	ch <- prometheus.MustNewConstMetric(c.Groups, prometheus.GaugeValue, float64(len(response.Groups)), organizationID)
+
+	for _, group := range response.Groups {
+		ch <- prometheus.MustNewConstMetric(
+			c.GroupMembers,
+			prometheus.GaugeValue,
+			float64(len(group.UserIDs)+len(group.ApplicationIDs)),
+			organizationID, group.ID, group.Name,
+		)
+	}
+}
+
+func (c *IAMCollector) CollectAPIKeys(ctx context.Context, ch chan<- prometheus.Metric, organizationID string) {
+	response, err := c.iamClient.ListAPIKeys(&iam.ListAPIKeysRequest{OrganizationID: &organizationID}, scw.WithAllPages(), scw.WithContext(ctx))
+
+	if err != nil {
+		c.errors.WithLabelValues("iam").Add(1)
+		_ = level.Warn(c.logger).Log("msg", "can't fetch the list of API keys", "err", err, "organization_id", organizationID)
+
+		return
+	}
+
+	_ = level.Debug(c.logger).Log("msg", fmt.Sprintf("found %d API keys", len(response.APIKeys)), "organization_id", organizationID)
+
+	ch <- prometheus.MustNewConstMetric(c.APIKeys, prometheus.GaugeValue, float64(len(response.APIKeys)), organizationID)
+
+	currentAccessKey, _ := c.client.GetAccessKey()
+
+	for _, apiKey := range response.APIKeys {
+		var bearerType, bearerID string
+
+		switch {
+		case apiKey.UserID != nil:
+			bearerType = "user"
+			bearerID = *apiKey.UserID
+		case apiKey.ApplicationID != nil:
+			bearerType = "application"
+			bearerID = *apiKey.ApplicationID
+		}
+
+		labelsAPIKey := []string{
+			organizationID,
+			apiKey.AccessKey,
+			apiKey.Description,
+			bearerType,
+			bearerID,
+			fmt.Sprint(apiKey.AccessKey == currentAccessKey),
+		}
+
+		if apiKey.CreatedAt != nil {
+			ch <- prometheus.MustNewConstMetric(c.APIKeyAge, prometheus.GaugeValue, time.Since(*apiKey.CreatedAt).Seconds(), labelsAPIKey...)
+		}
+
+		if apiKey.ExpiresAt != nil {
+			ch <- prometheus.MustNewConstMetric(c.APIKeyExpiresAt, prometheus.GaugeValue, float64(apiKey.ExpiresAt.Unix()), labelsAPIKey...)
+		}
+	}
+}
+
+func (c *IAMCollector) CollectPolicies(ctx context.Context, ch chan<- prometheus.Metric, organizationID string) {
+	response, err := c.iamClient.ListPolicies(&iam.ListPoliciesRequest{OrganizationID: organizationID}, scw.WithAllPages(), scw.WithContext(ctx))
+
+	if err != nil {
+		c.errors.WithLabelValues("iam").Add(1)
+		_ = level.Warn(c.logger).Log("msg", "can't fetch the list of policies", "err", err, "organization_id", organizationID)
+
+		return
+	}
+
+	_ = level.Debug(c.logger).Log("msg", fmt.Sprintf("found %d policies", len(response.Policies)), "organization_id", organizationID)
+
+	ch <- prometheus.MustNewConstMetric(c.Policies, prometheus.GaugeValue, float64(len(response.Policies)), organizationID)
+
+	for _, policy := range response.Policies {
+		ch <- prometheus.MustNewConstMetric(
+			c.PolicyRules,
+			prometheus.GaugeValue,
+			float64(policy.NbRules),
+			organizationID, policy.ID, policy.Name,
+		)
+	}
+}
+
+func (c *IAMCollector) CollectSSHKeys(ctx context.Context, ch chan<- prometheus.Metric, organizationID string) {
+	response, err := c.iamClient.ListSSHKeys(&iam.ListSSHKeysRequest{OrganizationID: &organizationID}, scw.WithAllPages(), scw.WithContext(ctx))
+
+	if err != nil {
+		c.errors.WithLabelValues("iam").Add(1)
+		_ = level.Warn(c.logger).Log("msg", "can't fetch the list of SSH keys", "err", err, "organization_id", organizationID)
+
+		return
+	}
+
+	_ = level.Debug(c.logger).Log("msg", fmt.Sprintf("found %d SSH keys", len(response.SSHKeys)), "organization_id", organizationID)
+
+	ch <- prometheus.MustNewConstMetric(c.SSHKeys, prometheus.GaugeValue, float64(len(response.SSHKeys)), organizationID)
+
+	for _, sshKey := range response.SSHKeys {
+		if sshKey.CreatedAt == nil {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.SSHKeyAge,
+			prometheus.GaugeValue,
+			time.Since(*sshKey.CreatedAt).Seconds(),
+			organizationID, sshKey.ProjectID, sshKey.ID, sshKey.Name, sshKey.Fingerprint, fmt.Sprint(sshKey.Disabled),
+		)
+	}
+}
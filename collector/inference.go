@@ -0,0 +1,151 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	inference "github.com/scaleway/scaleway-sdk-go/api/inference/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// InferenceCollector collects metrics about all Managed Inference deployments.
+type InferenceCollector struct {
+	logger          log.Logger
+	errors          *prometheus.CounterVec
+	inferenceClient *inference.API
+	timeout         time.Duration
+	regions         []scw.Region
+	projects        ProjectFilter
+	tags            TagFilter
+	tagLabels       TagLabels
+
+	Up   *prometheus.Desc
+	Size *prometheus.Desc
+}
+
+// NewInferenceCollector returns a new InferenceCollector.
+func NewInferenceCollector(logger log.Logger, errors *prometheus.CounterVec, client *scw.Client, timeout time.Duration, regions []scw.Region, projects ProjectFilter, tags TagFilter, tagLabels TagLabels) *InferenceCollector {
+	errors.WithLabelValues("inference").Add(0)
+
+	_ = level.Info(logger).Log("msg", "Inference collector enabled")
+
+	labels := []string{"id", "name", "region", "project_id"}
+
+	return &InferenceCollector{
+		logger:          logger,
+		errors:          errors,
+		inferenceClient: inference.NewAPI(client),
+		timeout:         timeout,
+		regions:         regions,
+		projects:        projects,
+		tags:            tags,
+		tagLabels:       tagLabels,
+
+		Up: prometheus.NewDesc(
+			"scaleway_inference_deployment_up",
+			"If 1 the deployment is ready, 0.5 when creating or deploying, 0 otherwise",
+			append(append([]string{}, labels...), append([]string{"model", "node_type", "status"}, tagLabels.Names()...)...), nil,
+		),
+		Size: prometheus.NewDesc(
+			"scaleway_inference_deployment_nodes",
+			"The number of nodes of the deployment",
+			labels, nil,
+		),
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+// collected by this Collector.
+func (c *InferenceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Up
+	ch <- c.Size
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *InferenceCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	for _, region := range c.regions {
+		response, err := c.inferenceClient.ListDeployments(&inference.ListDeploymentsRequest{Region: region}, scw.WithAllPages(), scw.WithContext(ctx))
+
+		if err != nil {
+			var responseError *scw.ResponseError
+
+			switch {
+			case errors.As(err, &responseError) && (responseError.StatusCode == http.StatusNotImplemented || responseError.StatusCode == http.StatusNotFound):
+				_ = level.Debug(c.logger).Log("msg", "Managed Inference is not supported in this region", "region", region)
+			default:
+				c.errors.WithLabelValues("inference").Add(1)
+				_ = level.Warn(c.logger).Log("msg", "can't fetch the list of inference deployments", "err", err, "region", region)
+			}
+
+			continue
+		}
+
+		_ = level.Debug(c.logger).Log("msg", fmt.Sprintf("found %d inference deployments", len(response.Deployments)), "region", region)
+
+		// a deployment listed on two pages must not be sent twice, or the whole scrape fails
+		emitted := map[string]bool{}
+
+		for _, deployment := range response.Deployments {
+			if !c.projects.Match(deployment.ProjectID) || !c.tags.Match(deployment.Tags) {
+				continue
+			}
+
+			if emitted[deployment.ID] {
+				_ = level.Debug(c.logger).Log("msg", "duplicated inference deployment", "region", region, "deploymentId", deployment.ID)
+
+				continue
+			}
+
+			emitted[deployment.ID] = true
+
+			c.CollectDeployment(ch, deployment)
+		}
+	}
+}
+
+// CollectDeployment sends the state and the size of a Managed Inference deployment.
+func (c *InferenceCollector) CollectDeployment(ch chan<- prometheus.Metric, deployment *inference.Deployment) {
+	labels := []string{
+		deployment.ID,
+		deployment.Name,
+		deployment.Region.String(),
+		deployment.ProjectID,
+	}
+
+	var active float64
+
+	switch deployment.Status {
+	case inference.DeploymentStatusReady:
+		active = 1.0
+	case inference.DeploymentStatusCreating:
+		active = 0.5
+	case inference.DeploymentStatusDeploying:
+		active = 0.5
+	case inference.DeploymentStatusDeleting:
+		active = 0.0
+	case inference.DeploymentStatusError:
+		active = 0.0
+	case inference.DeploymentStatusLocked:
+		active = 0.0
+	default:
+		active = 0.0
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.Up,
+		prometheus.GaugeValue,
+		active,
+		append(append([]string{}, labels...), append([]string{deployment.ModelName, deployment.NodeType, deployment.Status.String()}, c.tagLabels.Values(deployment.Tags)...)...)...,
+	)
+
+	ch <- prometheus.MustNewConstMetric(c.Size, prometheus.GaugeValue, float64(deployment.Size), labels...)
+}
@@ -0,0 +1,138 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// InstanceCollector collects metrics about all instance servers.
+type InstanceCollector struct {
+	logger         log.Logger
+	errors         *prometheus.CounterVec
+	instanceClient *instance.API
+	timeout        time.Duration
+	zones          []scw.Zone
+	projects       ProjectFilter
+	tags           TagFilter
+	cache          *ListingCache
+	tagLabels      TagLabels
+
+	Up *prometheus.Desc
+}
+
+// NewInstanceCollector returns a new InstanceCollector.
+func NewInstanceCollector(logger log.Logger, errors *prometheus.CounterVec, client *scw.Client, timeout time.Duration, zones []scw.Zone, projects ProjectFilter, tags TagFilter, cache *ListingCache, tagLabels TagLabels) *InstanceCollector {
+	errors.WithLabelValues("instance").Add(0)
+
+	_ = level.Info(logger).Log("msg", "Instance collector enabled")
+
+	labels := []string{"id", "name", "zone", "project_id", "type", "image"}
+
+	return &InstanceCollector{
+		logger:         logger,
+		errors:         errors,
+		instanceClient: instance.NewAPI(client),
+		timeout:        timeout,
+		zones:          zones,
+		projects:       projects,
+		tags:           tags,
+		cache:          cache,
+		tagLabels:      tagLabels,
+
+		Up: prometheus.NewDesc(
+			"scaleway_instance_up",
+			"If 1 the instance is up and running, 0.5 when starting or stopping, 0 otherwise",
+			append(append([]string{}, labels...), tagLabels.Names()...), nil,
+		),
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+// collected by this Collector.
+func (c *InstanceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Up
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *InstanceCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	for _, zone := range c.zones {
+		response, err := CachedListing(c.cache, "instance/"+zone.String(), func() (*instance.ListServersResponse, error) {
+			return c.instanceClient.ListServers(&instance.ListServersRequest{Zone: zone}, scw.WithAllPages(), scw.WithContext(ctx))
+		})
+
+		if err != nil {
+			var responseError *scw.ResponseError
+
+			switch {
+			case errors.As(err, &responseError) && responseError.StatusCode == http.StatusNotImplemented:
+				_ = level.Debug(c.logger).Log("msg", "Instance is not supported in this zone", "zone", zone)
+			default:
+				c.errors.WithLabelValues("instance").Add(1)
+				_ = level.Warn(c.logger).Log("msg", "can't fetch the list of instances", "err", err, "zone", zone)
+			}
+
+			continue
+		}
+
+		_ = level.Debug(c.logger).Log("msg", fmt.Sprintf("found %d instances", len(response.Servers)), "zone", zone)
+
+		for _, server := range response.Servers {
+			if !c.projects.Match(server.Project) || !c.tags.Match(server.Tags) {
+				continue
+			}
+
+			c.CollectInstance(ch, server)
+		}
+	}
+}
+
+// CollectInstance sends the state of an instance server.
+func (c *InstanceCollector) CollectInstance(ch chan<- prometheus.Metric, server *instance.Server) {
+	var image string
+
+	if server.Image != nil {
+		image = server.Image.Name
+	}
+
+	labels := []string{
+		server.ID,
+		server.Name,
+		server.Zone.String(),
+		server.Project,
+		server.CommercialType,
+		image,
+	}
+
+	var active float64
+
+	switch server.State {
+	case instance.ServerStateRunning:
+		active = 1.0
+	case instance.ServerStateStarting:
+		active = 0.5
+	case instance.ServerStateStopping:
+		active = 0.5
+	case instance.ServerStateStopped:
+		active = 0.0
+	case instance.ServerStateStoppedInPlace:
+		active = 0.0
+	case instance.ServerStateLocked:
+		active = 0.0
+	default:
+		active = 0.0
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.Up, prometheus.GaugeValue, active, append(labels, c.tagLabels.Values(server.Tags)...)...)
+}
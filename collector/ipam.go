@@ -0,0 +1,170 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/scaleway/scaleway-sdk-go/api/ipam/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// IPAMCollector collects metrics about the IP addresses allocated in the private networks.
+type IPAMCollector struct {
+	logger     log.Logger
+	errors     *prometheus.CounterVec
+	vpcClient  *vpc.API
+	ipamClient *ipam.API
+	timeout    time.Duration
+	regions    []scw.Region
+	projects   ProjectFilter
+	tags       TagFilter
+	tagLabels  TagLabels
+
+	PrivateNetworkAllocatedIPs *prometheus.Desc
+	SubnetAllocatedIPs         *prometheus.Desc
+	SubnetSize                 *prometheus.Desc
+	SubnetUtilization          *prometheus.Desc
+}
+
+// NewIPAMCollector returns a new IPAMCollector.
+func NewIPAMCollector(logger log.Logger, errors *prometheus.CounterVec, client *scw.Client, timeout time.Duration, regions []scw.Region, projects ProjectFilter, tags TagFilter, tagLabels TagLabels) *IPAMCollector {
+	errors.WithLabelValues("ipam").Add(0)
+
+	_ = level.Info(logger).Log("msg", "IPAM collector enabled")
+
+	labelsSubnet := []string{"private_network_id", "private_network_name", "region", "project_id", "subnet"}
+
+	return &IPAMCollector{
+		logger:     logger,
+		errors:     errors,
+		vpcClient:  vpc.NewAPI(client),
+		ipamClient: ipam.NewAPI(client),
+		timeout:    timeout,
+		regions:    regions,
+		projects:   projects,
+		tags:       tags,
+		tagLabels:  tagLabels,
+
+		PrivateNetworkAllocatedIPs: prometheus.NewDesc(
+			"scaleway_ipam_private_network_allocated_ips",
+			"The number of IP addresses allocated in the private network",
+			append([]string{"private_network_id", "private_network_name", "region", "project_id"}, tagLabels.Names()...), nil,
+		),
+		SubnetAllocatedIPs: prometheus.NewDesc(
+			"scaleway_ipam_subnet_allocated_ips",
+			"The number of IP addresses allocated in the subnet",
+			labelsSubnet, nil,
+		),
+		SubnetSize: prometheus.NewDesc(
+			"scaleway_ipam_subnet_size",
+			"The number of IP addresses of the subnet",
+			labelsSubnet, nil,
+		),
+		SubnetUtilization: prometheus.NewDesc(
+			"scaleway_ipam_subnet_utilization_ratio",
+			"The ratio of allocated IP addresses over the size of the subnet",
+			labelsSubnet, nil,
+		),
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+// collected by this Collector.
+func (c *IPAMCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.PrivateNetworkAllocatedIPs
+	ch <- c.SubnetAllocatedIPs
+	ch <- c.SubnetSize
+	ch <- c.SubnetUtilization
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *IPAMCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	for _, region := range c.regions {
+		privateNetworks, err := c.vpcClient.ListPrivateNetworks(&vpc.ListPrivateNetworksRequest{Region: region}, scw.WithAllPages(), scw.WithContext(ctx))
+
+		if err != nil {
+			var responseError *scw.ResponseError
+
+			switch {
+			case errors.As(err, &responseError) && responseError.StatusCode == http.StatusNotImplemented:
+				_ = level.Debug(c.logger).Log("msg", "IPAM is not supported in this region", "region", region)
+			default:
+				c.errors.WithLabelValues("ipam").Add(1)
+				_ = level.Warn(c.logger).Log("msg", "can't fetch the list of private networks", "err", err, "region", region)
+			}
+
+			continue
+		}
+
+		ips, err := c.ipamClient.ListIPs(&ipam.ListIPsRequest{Region: region}, scw.WithAllPages(), scw.WithContext(ctx))
+
+		if err != nil {
+			c.errors.WithLabelValues("ipam").Add(1)
+			_ = level.Warn(c.logger).Log("msg", "can't fetch the list of IP addresses", "err", err, "region", region)
+
+			continue
+		}
+
+		_ = level.Debug(c.logger).Log("msg", fmt.Sprintf("found %d IP addresses", len(ips.IPs)), "region", region)
+
+		allocatedPerPrivateNetwork := make(map[string]int)
+		allocatedPerSubnet := make(map[string]int)
+
+		for _, ip := range ips.IPs {
+			if ip.Source == nil || ip.Source.PrivateNetworkID == nil {
+				continue
+			}
+
+			allocatedPerPrivateNetwork[*ip.Source.PrivateNetworkID]++
+
+			if ip.Source.SubnetID != nil {
+				allocatedPerSubnet[*ip.Source.SubnetID]++
+			}
+		}
+
+		for _, privateNetwork := range privateNetworks.PrivateNetworks {
+			if !c.projects.Match(privateNetwork.ProjectID) || !c.tags.Match(privateNetwork.Tags) {
+				continue
+			}
+
+			ch <- prometheus.MustNewConstMetric(
+				c.PrivateNetworkAllocatedIPs,
+				prometheus.GaugeValue,
+				float64(allocatedPerPrivateNetwork[privateNetwork.ID]),
+				append([]string{privateNetwork.ID, privateNetwork.Name, privateNetwork.Region.String(), privateNetwork.ProjectID}, c.tagLabels.Values(privateNetwork.Tags)...)...,
+			)
+
+			for _, subnet := range privateNetwork.Subnets {
+				labels := []string{
+					privateNetwork.ID,
+					privateNetwork.Name,
+					privateNetwork.Region.String(),
+					privateNetwork.ProjectID,
+					subnet.Subnet.String(),
+				}
+
+				allocated := float64(allocatedPerSubnet[subnet.ID])
+
+				ch <- prometheus.MustNewConstMetric(c.SubnetAllocatedIPs, prometheus.GaugeValue, allocated, labels...)
+
+				ones, bits := subnet.Subnet.Mask.Size()
+
+				size := math.Pow(2, float64(bits-ones))
+
+				ch <- prometheus.MustNewConstMetric(c.SubnetSize, prometheus.GaugeValue, size, labels...)
+				ch <- prometheus.MustNewConstMetric(c.SubnetUtilization, prometheus.GaugeValue, allocated/size, labels...)
+			}
+		}
+	}
+}
@@ -0,0 +1,368 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// KubernetesCollector collects metrics about all Kapsule clusters.
+type KubernetesCollector struct {
+	logger    log.Logger
+	errors    *prometheus.CounterVec
+	client    *scw.Client
+	k8sClient *k8s.API
+	timeout   time.Duration
+	regions   []scw.Region
+	projects  ProjectFilter
+	tags      TagFilter
+	cache     *ListingCache
+	tagLabels TagLabels
+
+	ClusterUp               *prometheus.Desc
+	ClusterNodes            *prometheus.Desc
+	ClusterUpgradeAvailable *prometheus.Desc
+	PoolUp                  *prometheus.Desc
+	PoolSize                *prometheus.Desc
+	PoolMinSize             *prometheus.Desc
+	PoolMaxSize             *prometheus.Desc
+	PoolAutoscaling         *prometheus.Desc
+	PoolVersionDrift        *prometheus.Desc
+	NodeUp                  *prometheus.Desc
+}
+
+// NewKubernetesCollector returns a new KubernetesCollector.
+func NewKubernetesCollector(logger log.Logger, errors *prometheus.CounterVec, client *scw.Client, timeout time.Duration, regions []scw.Region, projects ProjectFilter, tags TagFilter, cache *ListingCache, tagLabels TagLabels) *KubernetesCollector {
+	errors.WithLabelValues("kubernetes").Add(0)
+
+	_ = level.Info(logger).Log("msg", "Kubernetes collector enabled")
+
+	labels := []string{"id", "name", "region", "project_id", "type", "version", "cni"}
+
+	labelsCluster := []string{"id", "name", "region", "project_id"}
+
+	labelsPool := []string{"cluster_id", "id", "name", "zone"}
+
+	labelsNode := []string{"cluster_id", "pool_id", "id", "name", "status"}
+
+	return &KubernetesCollector{
+		logger:    logger,
+		errors:    errors,
+		client:    client,
+		k8sClient: k8s.NewAPI(client),
+		timeout:   timeout,
+		regions:   regions,
+		projects:  projects,
+		tags:      tags,
+		cache:     cache,
+		tagLabels: tagLabels,
+
+		ClusterUp: prometheus.NewDesc(
+			"scaleway_k8s_cluster_up",
+			"If 1 the cluster is ready, 0.5 when creating or updating, 0 otherwise",
+			append(append([]string{}, labels...), tagLabels.Names()...), nil,
+		),
+		ClusterNodes: prometheus.NewDesc(
+			"scaleway_k8s_cluster_nodes",
+			"The number of nodes in the cluster",
+			labelsCluster, nil,
+		),
+		ClusterUpgradeAvailable: prometheus.NewDesc(
+			"scaleway_k8s_cluster_upgrade_available",
+			"If 1 a newer Kubernetes version is available for the cluster, 0 otherwise",
+			labelsCluster, nil,
+		),
+		PoolUp: prometheus.NewDesc(
+			"scaleway_k8s_pool_up",
+			"If 1 the pool is ready, 0.5 when scaling or upgrading, 0 otherwise",
+			append(append([]string{}, labelsPool...), "node_type", "version"), nil,
+		),
+		PoolSize: prometheus.NewDesc(
+			"scaleway_k8s_pool_size",
+			"The current number of nodes in the pool",
+			labelsPool, nil,
+		),
+		PoolMinSize: prometheus.NewDesc(
+			"scaleway_k8s_pool_min_size",
+			"The minimum number of nodes allowed in the pool",
+			labelsPool, nil,
+		),
+		PoolMaxSize: prometheus.NewDesc(
+			"scaleway_k8s_pool_max_size",
+			"The maximum number of nodes allowed in the pool",
+			labelsPool, nil,
+		),
+		PoolAutoscaling: prometheus.NewDesc(
+			"scaleway_k8s_pool_autoscaling",
+			"If 1 the autoscaling is enabled on the pool, 0 otherwise",
+			labelsPool, nil,
+		),
+		PoolVersionDrift: prometheus.NewDesc(
+			"scaleway_k8s_pool_version_drift",
+			"If 1 the Kubernetes version of the pool differs from the control plane one, 0 otherwise",
+			labelsPool, nil,
+		),
+		NodeUp: prometheus.NewDesc(
+			"scaleway_k8s_node_up",
+			"If 1 the node is ready, 0.5 when being created, upgraded or rebooted, 0 otherwise",
+			labelsNode, nil,
+		),
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+// collected by this Collector.
+func (c *KubernetesCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.ClusterUp
+	ch <- c.ClusterNodes
+	ch <- c.ClusterUpgradeAvailable
+	ch <- c.PoolUp
+	ch <- c.PoolSize
+	ch <- c.PoolMinSize
+	ch <- c.PoolMaxSize
+	ch <- c.PoolAutoscaling
+	ch <- c.PoolVersionDrift
+	ch <- c.NodeUp
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *KubernetesCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for _, region := range c.regions {
+		response, err := CachedListing(c.cache, "kubernetes/"+region.String(), func() (*k8s.ListClustersResponse, error) {
+			return c.k8sClient.ListClusters(&k8s.ListClustersRequest{Region: region}, scw.WithAllPages(), scw.WithContext(ctx))
+		})
+
+		if err != nil {
+			var responseError *scw.ResponseError
+
+			switch {
+			case errors.As(err, &responseError) && responseError.StatusCode == http.StatusNotImplemented:
+				_ = level.Debug(c.logger).Log("msg", "Kubernetes is not supported in this region", "region", region)
+			default:
+				c.errors.WithLabelValues("kubernetes").Add(1)
+				_ = level.Warn(c.logger).Log("msg", "can't fetch the list of clusters", "err", err, "region", region)
+			}
+
+			continue
+		}
+
+		_ = level.Debug(c.logger).Log("msg", fmt.Sprintf("found %d kubernetes clusters", len(response.Clusters)), "region", region)
+
+		for _, cluster := range response.Clusters {
+			if !c.projects.Match(cluster.ProjectID) || !c.tags.Match(cluster.Tags) {
+				continue
+			}
+
+			wg.Add(1)
+
+			_ = level.Debug(c.logger).Log("msg", fmt.Sprintf("Fetching metrics for cluster : %s", cluster.Name), "region", region)
+
+			go c.FetchClusterMetrics(ctx, &wg, ch, cluster)
+		}
+	}
+}
+
+func (c *KubernetesCollector) FetchClusterMetrics(ctx context.Context, parentWg *sync.WaitGroup, ch chan<- prometheus.Metric, cluster *k8s.Cluster) {
+	defer parentWg.Done()
+
+	labels := []string{
+		cluster.ID,
+		cluster.Name,
+		cluster.Region.String(),
+		cluster.ProjectID,
+		cluster.Type,
+		cluster.Version,
+		cluster.Cni.String(),
+	}
+
+	labelsCluster := []string{
+		cluster.ID,
+		cluster.Name,
+		cluster.Region.String(),
+		cluster.ProjectID,
+	}
+
+	var active float64
+
+	switch cluster.Status {
+	case k8s.ClusterStatusReady:
+		active = 1.0
+	case k8s.ClusterStatusCreating:
+		active = 0.5
+	case k8s.ClusterStatusUpdating:
+		active = 0.5
+	case k8s.ClusterStatusDeleting:
+		active = 0.5
+	case k8s.ClusterStatusPoolRequired:
+		active = 0.0
+	case k8s.ClusterStatusLocked:
+		active = 0.0
+	case k8s.ClusterStatusDeleted:
+		active = 0.0
+	case k8s.ClusterStatusUnknown:
+		active = 0.0
+	default:
+		active = 0.0
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.ClusterUp, prometheus.GaugeValue, active, append(labels, c.tagLabels.Values(cluster.Tags)...)...)
+
+	var upgradeAvailable float64
+
+	if cluster.UpgradeAvailable {
+		upgradeAvailable = 1.0
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.ClusterUpgradeAvailable, prometheus.GaugeValue, upgradeAvailable, labelsCluster...)
+
+	nodes, err := c.k8sClient.ListNodes(&k8s.ListNodesRequest{Region: cluster.Region, ClusterID: cluster.ID}, scw.WithAllPages(), scw.WithContext(ctx))
+
+	if err != nil {
+		c.errors.WithLabelValues("kubernetes").Add(1)
+		_ = level.Warn(c.logger).Log(
+			"msg", "can't fetch the list of nodes for the cluster",
+			"region", cluster.Region,
+			"clusterId", cluster.ID,
+			"clusterName", cluster.Name,
+			"err", err,
+		)
+
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.ClusterNodes, prometheus.GaugeValue, float64(len(nodes.Nodes)), labelsCluster...)
+
+	for _, node := range nodes.Nodes {
+		c.CollectNode(ch, node)
+	}
+
+	pools, err := c.k8sClient.ListPools(&k8s.ListPoolsRequest{Region: cluster.Region, ClusterID: cluster.ID}, scw.WithAllPages(), scw.WithContext(ctx))
+
+	if err != nil {
+		c.errors.WithLabelValues("kubernetes").Add(1)
+		_ = level.Warn(c.logger).Log(
+			"msg", "can't fetch the list of pools for the cluster",
+			"region", cluster.Region,
+			"clusterId", cluster.ID,
+			"clusterName", cluster.Name,
+			"err", err,
+		)
+
+		return
+	}
+
+	for _, pool := range pools.Pools {
+		c.CollectPool(ch, cluster, pool)
+	}
+}
+
+func (c *KubernetesCollector) CollectPool(ch chan<- prometheus.Metric, cluster *k8s.Cluster, pool *k8s.Pool) {
+	labelsPool := []string{
+		pool.ClusterID,
+		pool.ID,
+		pool.Name,
+		pool.Zone.String(),
+	}
+
+	var active float64
+
+	switch pool.Status {
+	case k8s.PoolStatusReady:
+		active = 1.0
+	case k8s.PoolStatusScaling:
+		active = 0.5
+	case k8s.PoolStatusUpgrading:
+		active = 0.5
+	case k8s.PoolStatusDeleting:
+		active = 0.5
+	case k8s.PoolStatusWarning:
+		active = 0.0
+	case k8s.PoolStatusLocked:
+		active = 0.0
+	case k8s.PoolStatusDeleted:
+		active = 0.0
+	case k8s.PoolStatusUnknown:
+		active = 0.0
+	default:
+		active = 0.0
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.PoolUp, prometheus.GaugeValue, active, append(append([]string{}, labelsPool...), pool.NodeType, pool.Version)...)
+	ch <- prometheus.MustNewConstMetric(c.PoolSize, prometheus.GaugeValue, float64(pool.Size), labelsPool...)
+	ch <- prometheus.MustNewConstMetric(c.PoolMinSize, prometheus.GaugeValue, float64(pool.MinSize), labelsPool...)
+	ch <- prometheus.MustNewConstMetric(c.PoolMaxSize, prometheus.GaugeValue, float64(pool.MaxSize), labelsPool...)
+
+	var autoscaling float64
+
+	if pool.Autoscaling {
+		autoscaling = 1.0
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.PoolAutoscaling, prometheus.GaugeValue, autoscaling, labelsPool...)
+
+	var versionDrift float64
+
+	if pool.Version != cluster.Version {
+		versionDrift = 1.0
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.PoolVersionDrift, prometheus.GaugeValue, versionDrift, labelsPool...)
+}
+
+func (c *KubernetesCollector) CollectNode(ch chan<- prometheus.Metric, node *k8s.Node) {
+	labelsNode := []string{
+		node.ClusterID,
+		node.PoolID,
+		node.ID,
+		node.Name,
+		node.Status.String(),
+	}
+
+	var active float64
+
+	switch node.Status {
+	case k8s.NodeStatusReady:
+		active = 1.0
+	case k8s.NodeStatusCreating:
+		active = 0.5
+	case k8s.NodeStatusStarting:
+		active = 0.5
+	case k8s.NodeStatusRegistering:
+		active = 0.5
+	case k8s.NodeStatusUpgrading:
+		active = 0.5
+	case k8s.NodeStatusRebooting:
+		active = 0.5
+	case k8s.NodeStatusDeleting:
+		active = 0.5
+	case k8s.NodeStatusNotReady:
+		active = 0.0
+	case k8s.NodeStatusCreationError:
+		active = 0.0
+	case k8s.NodeStatusLocked:
+		active = 0.0
+	case k8s.NodeStatusDeleted:
+		active = 0.0
+	case k8s.NodeStatusUnknown:
+		active = 0.0
+	default:
+		active = 0.0
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.NodeUp, prometheus.GaugeValue, active, labelsNode...)
+}
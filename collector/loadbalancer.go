@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -17,63 +19,171 @@ import (
 	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
+// LoadBalancerOptions are the optional settings of the LoadBalancerCollector.
+type LoadBalancerOptions struct {
+	// CockpitURL of the Cockpit metrics data source to read the loadbalancer timeseries from, instead of the private API.
+	CockpitURL string
+	// CockpitToken used to query the Cockpit data source.
+	CockpitToken string
+	// TagLabels are the tags of the loadbalancers exposed as labels of the scaleway_loadbalancer_info metric.
+	TagLabels TagLabels
+}
+
 // LoadBalancerCollector collects metrics about all loadbalancers.
 type LoadBalancerCollector struct {
-	logger   log.Logger
-	errors   *prometheus.CounterVec
-	client   *scw.Client
-	lbClient *lb.ZonedAPI
-	timeout  time.Duration
-	zones    []scw.Zone
+	logger      log.Logger
+	errors      *prometheus.CounterVec
+	client      *scw.Client
+	lbClient    *lb.ZonedAPI
+	timeout     time.Duration
+	zones       []scw.Zone
+	projects    ProjectFilter
+	tags        TagFilter
+	cache       *ListingCache
+	unmapped    bool
+	passthrough *PassthroughMetrics
+	timestamps  bool
+	cockpit     *CockpitClient
+	options     LoadBalancerOptions
 
 	Up              *prometheus.Desc
+	Info            *prometheus.Desc
 	NetworkReceive  *prometheus.Desc
 	NetworkTransmit *prometheus.Desc
 	Connection      *prometheus.Desc
 	NewConnection   *prometheus.Desc
+	Frontend        *prometheus.Desc
+	ServersHealthy  *prometheus.Desc
+	ServersFailing  *prometheus.Desc
+	ACLRules        *prometheus.Desc
+	ACLCatchAll     *prometheus.Desc
+	IPs             *prometheus.Desc
+	IPInfo          *prometheus.Desc
+	Routes          *prometheus.Desc
+	FrontendRoutes  *prometheus.Desc
+	PrivateNetworks *prometheus.Desc
+	PrivateNetwork  *prometheus.Desc
 }
 
 // NewLoadBalancerCollector returns a new LoadBalancerCollector.
-func NewLoadBalancerCollector(logger log.Logger, errors *prometheus.CounterVec, client *scw.Client, timeout time.Duration, zones []scw.Zone) *LoadBalancerCollector {
+func NewLoadBalancerCollector(logger log.Logger, errors *prometheus.CounterVec, client *scw.Client, timeout time.Duration, zones []scw.Zone, projects ProjectFilter, tags TagFilter, cache *ListingCache, unmapped bool, timestamps bool, options LoadBalancerOptions) *LoadBalancerCollector {
 	errors.WithLabelValues("loadbalancer").Add(0)
 
 	_ = level.Info(logger).Log("msg", "Loadbalancer collector enabled")
 
-	labels := []string{"id", "name", "zone", "type"}
+	var cockpit *CockpitClient
+
+	if options.CockpitURL != "" {
+		cockpit = NewCockpitClient(options.CockpitURL, options.CockpitToken, timeout)
+	}
+
+	labels := []string{"id", "name", "zone", "project_id", "type"}
+
+	labelsFrontend := []string{"id", "name", "zone", "frontend_id", "frontend_name"}
+
+	labelsBackend := []string{"id", "name", "zone", "backend_id", "backend_name"}
 
 	return &LoadBalancerCollector{
-		logger:   logger,
-		errors:   errors,
-		client:   client,
-		lbClient: lb.NewZonedAPI(client),
-		timeout:  timeout,
-		zones:    zones,
+		logger:      logger,
+		errors:      errors,
+		client:      client,
+		lbClient:    lb.NewZonedAPI(client),
+		timeout:     timeout,
+		zones:       zones,
+		projects:    projects,
+		tags:        tags,
+		cache:       cache,
+		unmapped:    unmapped,
+		passthrough: NewPassthroughMetrics(),
+		timestamps:  timestamps,
+		cockpit:     cockpit,
+		options:     options,
 
 		Up: prometheus.NewDesc(
 			"scaleway_loadbalancer_up",
 			"If 1 the loadbalancer is up and running, 0.5 when migrating, 0 otherwise",
 			labels, nil,
 		),
+		Info: prometheus.NewDesc(
+			"scaleway_loadbalancer_info",
+			"The loadbalancer information",
+			append(append([]string{}, labels...), options.TagLabels.Names()...), nil,
+		),
 		NetworkReceive: prometheus.NewDesc(
 			"scaleway_loadbalancer_network_receive_bits_sec",
-			"LoadBalancer's ", // TODO
+			"LoadBalancer's inbound network throughput in bits per second",
 			labels, nil,
 		),
 		NetworkTransmit: prometheus.NewDesc(
 			"scaleway_loadbalancer_network_transmit_bits_sec",
-			"LoadBalancer's ", // TODO
+			"LoadBalancer's outbound network throughput in bits per second",
 			labels, nil,
 		),
 		Connection: prometheus.NewDesc(
 			"scaleway_loadbalancer_total_connections",
-			"LoadBalancer's ", // TODO
+			"LoadBalancer's current connection rate per second",
 			labels, nil,
 		),
 		NewConnection: prometheus.NewDesc(
 			"scaleway_loadbalancer_new_connection_rate_sec",
-			"LoadBalancer's ", // TODO
+			"LoadBalancer's new connection rate per second",
+			labels, nil,
+		),
+		Frontend: prometheus.NewDesc(
+			"scaleway_loadbalancer_frontend_info",
+			"LoadBalancer's frontend information, with its inbound port, backend and certificates",
+			append(append([]string{}, labelsFrontend...), "inbound_port", "backend_id", "backend_name", "certificate_ids"), nil,
+		),
+		IPs: prometheus.NewDesc(
+			"scaleway_loadbalancer_ips",
+			"Number of flexible IPs attached to the loadbalancer per family",
+			append(append([]string{}, labels...), "family"), nil,
+		),
+		IPInfo: prometheus.NewDesc(
+			"scaleway_loadbalancer_ip_info",
+			"Flexible IP attached to the loadbalancer, it can be kept and reused when the loadbalancer is deleted",
+			[]string{"id", "name", "zone", "ip_id", "address", "family", "reverse"}, nil,
+		),
+		Routes: prometheus.NewDesc(
+			"scaleway_loadbalancer_routes",
+			"Number of routes of the loadbalancer",
 			labels, nil,
 		),
+		FrontendRoutes: prometheus.NewDesc(
+			"scaleway_loadbalancer_frontend_routes",
+			"Number of routes of the frontend",
+			labelsFrontend, nil,
+		),
+		PrivateNetworks: prometheus.NewDesc(
+			"scaleway_loadbalancer_private_networks",
+			"Number of private networks the loadbalancer is attached to",
+			labels, nil,
+		),
+		PrivateNetwork: prometheus.NewDesc(
+			"scaleway_loadbalancer_private_network_info",
+			"Private network the loadbalancer is attached to, with the status of the attachment",
+			[]string{"id", "name", "zone", "private_network_id", "status"}, nil,
+		),
+		ACLRules: prometheus.NewDesc(
+			"scaleway_loadbalancer_frontend_acl_rules",
+			"Number of ACL rules of the frontend",
+			labelsFrontend, nil,
+		),
+		ACLCatchAll: prometheus.NewDesc(
+			"scaleway_loadbalancer_frontend_acl_catch_all",
+			"If 1 an ACL rule of the frontend matches any IP without HTTP filter with the action (allow or deny), 0 otherwise",
+			append(append([]string{}, labelsFrontend...), "action"), nil,
+		),
+		ServersHealthy: prometheus.NewDesc(
+			"scaleway_loadbalancer_backend_servers_healthy",
+			"Number of servers of the backend marked as running by their health checks",
+			labelsBackend, nil,
+		),
+		ServersFailing: prometheus.NewDesc(
+			"scaleway_loadbalancer_backend_servers_unhealthy",
+			"Number of servers of the backend not marked as running by their health checks",
+			labelsBackend, nil,
+		),
 	}
 }
 
@@ -81,10 +191,22 @@ func NewLoadBalancerCollector(logger log.Logger, errors *prometheus.CounterVec,
 // collected by this Collector.
 func (c *LoadBalancerCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.Up
+	ch <- c.Info
 	ch <- c.NetworkReceive
 	ch <- c.NetworkTransmit
 	ch <- c.Connection
 	ch <- c.NewConnection
+	ch <- c.Frontend
+	ch <- c.ServersHealthy
+	ch <- c.ServersFailing
+	ch <- c.ACLRules
+	ch <- c.ACLCatchAll
+	ch <- c.IPs
+	ch <- c.IPInfo
+	ch <- c.Routes
+	ch <- c.FrontendRoutes
+	ch <- c.PrivateNetworks
+	ch <- c.PrivateNetwork
 }
 
 // LbMetrics InstanceMetrics: instance metrics.
@@ -95,12 +217,14 @@ type LbMetrics struct {
 
 // Collect is called by the Prometheus registry when collecting metrics.
 func (c *LoadBalancerCollector) Collect(ch chan<- prometheus.Metric) {
-	_, cancel := context.WithTimeout(context.Background(), c.timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
 	defer cancel()
 
 	for _, zone := range c.zones {
 		// create a list to hold our loadbalancers
-		response, err := c.lbClient.ListLBs(&lb.ZonedAPIListLBsRequest{Zone: zone}, scw.WithAllPages())
+		response, err := CachedListing(c.cache, "loadbalancer/"+zone.String(), func() (*lb.ListLBsResponse, error) {
+			return c.lbClient.ListLBs(&lb.ZonedAPIListLBsRequest{Zone: zone}, scw.WithAllPages(), scw.WithContext(ctx))
+		})
 
 		if err != nil {
 			var responseError *scw.ResponseError
@@ -108,12 +232,12 @@ func (c *LoadBalancerCollector) Collect(ch chan<- prometheus.Metric) {
 			switch {
 			case errors.As(err, &responseError) && responseError.StatusCode == http.StatusNotImplemented:
 				_ = level.Debug(c.logger).Log("msg", "Loadbalancer is not supported in this zone", "zone", zone)
-				return
+				continue
 			default:
 				c.errors.WithLabelValues("loadbalancer").Add(1)
 				_ = level.Warn(c.logger).Log("msg", "can't fetch the list of loadbalancers", "err", err, "zone", zone)
 
-				return
+				continue
 			}
 		}
 
@@ -123,30 +247,30 @@ func (c *LoadBalancerCollector) Collect(ch chan<- prometheus.Metric) {
 		defer wg.Wait()
 
 		for _, loadbalancer := range response.LBs {
+			if !c.projects.Match(loadbalancer.ProjectID) || !c.tags.Match(loadbalancer.Tags) {
+				continue
+			}
+
 			wg.Add(1)
 
 			_ = level.Debug(c.logger).Log("msg", fmt.Sprintf("Fetching metrics for loadbalancer : %s", loadbalancer.Name), "zone", zone)
 
-			go c.FetchLoadbalancerMetrics(&wg, ch, loadbalancer)
+			go c.FetchLoadbalancerMetrics(ctx, &wg, ch, loadbalancer)
 		}
 	}
 }
 
-func (c *LoadBalancerCollector) FetchLoadbalancerMetrics(parentWg *sync.WaitGroup, ch chan<- prometheus.Metric, loadbalancer *lb.LB) {
+func (c *LoadBalancerCollector) FetchLoadbalancerMetrics(ctx context.Context, parentWg *sync.WaitGroup, ch chan<- prometheus.Metric, loadbalancer *lb.LB) {
 	defer parentWg.Done()
 
 	labels := []string{
 		loadbalancer.ID,
 		loadbalancer.Name,
 		loadbalancer.Zone.String(),
+		loadbalancer.ProjectID,
 		loadbalancer.Type,
 	}
 
-	// TODO check if it is possible to add loadbalancer tag as labels
-	// for _, tags := range instance.Tags {
-	//     labels = append(labels, tags)
-	// }
-
 	var active float64
 
 	switch loadbalancer.Status {
@@ -178,21 +302,23 @@ func (c *LoadBalancerCollector) FetchLoadbalancerMetrics(parentWg *sync.WaitGrou
 
 	ch <- prometheus.MustNewConstMetric(c.Up, prometheus.GaugeValue, active, labels...)
 
-	query := url.Values{}
+	ch <- prometheus.MustNewConstMetric(c.Info, prometheus.GaugeValue, 1.0, append(append([]string{}, labels...), c.options.TagLabels.Values(loadbalancer.Tags)...)...)
 
-	query.Add("start_date", time.Now().Add(-1*time.Hour).Format(time.RFC3339))
-	query.Add("end_date", time.Now().Format(time.RFC3339))
+	c.CollectIPs(ch, loadbalancer, labels)
+	c.CollectPrivateNetworks(ctx, ch, loadbalancer, labels)
 
-	scwReq := &scw.ScalewayRequest{
-		Method:  "GET",
-		Path:    "/lb-private/v1/zones/" + fmt.Sprint(loadbalancer.Zone) + "/lbs/" + fmt.Sprint(loadbalancer.ID) + "/metrics",
-		Query:   query,
-		Headers: http.Header{},
+	ch <- prometheus.MustNewConstMetric(c.Routes, prometheus.GaugeValue, float64(loadbalancer.RouteCount), labels...)
+
+	frontends := c.CollectFrontends(ctx, ch, loadbalancer)
+
+	for _, frontend := range frontends {
+		c.CollectFrontendACLs(ctx, ch, loadbalancer, frontend)
+		c.CollectFrontendRoutes(ctx, ch, loadbalancer, frontend)
 	}
 
-	var metricResponse LbMetrics
+	c.CollectBackendHealth(ctx, ch, loadbalancer)
 
-	err := c.client.Do(scwReq, &metricResponse)
+	timeseriesList, err := c.FetchTimeseries(ctx, loadbalancer)
 
 	if err != nil {
 		c.errors.WithLabelValues("loadbalancer").Add(1)
@@ -207,7 +333,7 @@ func (c *LoadBalancerCollector) FetchLoadbalancerMetrics(parentWg *sync.WaitGrou
 		return
 	}
 
-	for _, timeseries := range metricResponse.Timeseries {
+	for _, timeseries := range timeseriesList {
 		var series *prometheus.Desc
 
 		switch timeseries.Name {
@@ -223,6 +349,14 @@ func (c *LoadBalancerCollector) FetchLoadbalancerMetrics(parentWg *sync.WaitGrou
 			// Should export metric for this ?
 			continue
 		default:
+			if c.unmapped {
+				if metric, ok := c.passthrough.Metric("loadbalancer", timeseries, []string{"id", "name", "zone", "project_id", "type"}, labels, c.timestamps); ok {
+					ch <- metric
+				}
+
+				continue
+			}
+
 			_ = level.Debug(c.logger).Log(
 				"msg", "unmapped scaleway metric",
 				"zone", loadbalancer.Zone,
@@ -235,7 +369,7 @@ func (c *LoadBalancerCollector) FetchLoadbalancerMetrics(parentWg *sync.WaitGrou
 		}
 
 		if len(timeseries.Points) == 0 {
-			c.errors.WithLabelValues("database").Add(1)
+			c.errors.WithLabelValues("loadbalancer").Add(1)
 			_ = level.Warn(c.logger).Log(
 				"msg", "no data were returned for the metric",
 				"loadbalancerName", loadbalancer.Name,
@@ -252,8 +386,276 @@ func (c *LoadBalancerCollector) FetchLoadbalancerMetrics(parentWg *sync.WaitGrou
 			return timeseries.Points[i].Timestamp.Before(timeseries.Points[j].Timestamp)
 		})
 
-		value := float64(timeseries.Points[len(timeseries.Points)-1].Value)
+		point := timeseries.Points[len(timeseries.Points)-1]
+
+		ch <- withPointTimestamp(prometheus.MustNewConstMetric(series, prometheus.GaugeValue, float64(point.Value), labels...), point, c.timestamps)
+	}
+}
+
+func (c *LoadBalancerCollector) CollectFrontends(ctx context.Context, ch chan<- prometheus.Metric, loadbalancer *lb.LB) []*lb.Frontend {
+	response, err := c.lbClient.ListFrontends(&lb.ZonedAPIListFrontendsRequest{Zone: loadbalancer.Zone, LBID: loadbalancer.ID}, scw.WithAllPages(), scw.WithContext(ctx))
+
+	if err != nil {
+		c.errors.WithLabelValues("loadbalancer").Add(1)
+		_ = level.Warn(c.logger).Log(
+			"msg", "can't fetch the list of frontends of the loadbalancer",
+			"zone", loadbalancer.Zone,
+			"loadbalancerId", loadbalancer.ID,
+			"loadbalancerName", loadbalancer.Name,
+			"err", err,
+		)
+
+		return nil
+	}
+
+	for _, frontend := range response.Frontends {
+		var backendID, backendName string
+
+		if frontend.Backend != nil {
+			backendID = frontend.Backend.ID
+			backendName = frontend.Backend.Name
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.Frontend,
+			prometheus.GaugeValue,
+			1.0,
+			loadbalancer.ID,
+			loadbalancer.Name,
+			loadbalancer.Zone.String(),
+			frontend.ID,
+			frontend.Name,
+			fmt.Sprint(frontend.InboundPort),
+			backendID,
+			backendName,
+			strings.Join(frontend.CertificateIDs, ","),
+		)
+	}
+
+	return response.Frontends
+}
+
+func (c *LoadBalancerCollector) CollectBackendHealth(ctx context.Context, ch chan<- prometheus.Metric, loadbalancer *lb.LB) {
+	backends, err := c.lbClient.ListBackends(&lb.ZonedAPIListBackendsRequest{Zone: loadbalancer.Zone, LBID: loadbalancer.ID}, scw.WithAllPages(), scw.WithContext(ctx))
+
+	if err != nil {
+		c.errors.WithLabelValues("loadbalancer").Add(1)
+		_ = level.Warn(c.logger).Log(
+			"msg", "can't fetch the list of backends of the loadbalancer",
+			"zone", loadbalancer.Zone,
+			"loadbalancerId", loadbalancer.ID,
+			"loadbalancerName", loadbalancer.Name,
+			"err", err,
+		)
+
+		return
+	}
+
+	stats, err := c.lbClient.ListBackendStats(&lb.ZonedAPIListBackendStatsRequest{Zone: loadbalancer.Zone, LBID: loadbalancer.ID}, scw.WithAllPages(), scw.WithContext(ctx))
+
+	if err != nil {
+		c.errors.WithLabelValues("loadbalancer").Add(1)
+		_ = level.Warn(c.logger).Log(
+			"msg", "can't fetch the health of the backend servers of the loadbalancer",
+			"zone", loadbalancer.Zone,
+			"loadbalancerId", loadbalancer.ID,
+			"loadbalancerName", loadbalancer.Name,
+			"err", err,
+		)
+
+		return
+	}
+
+	healthy := map[string]float64{}
+
+	unhealthy := map[string]float64{}
+
+	for _, server := range stats.BackendServersStats {
+		if server.ServerState == lb.BackendServerStatsServerStateRunning {
+			healthy[server.BackendID]++
+		} else {
+			unhealthy[server.BackendID]++
+		}
+	}
+
+	for _, backend := range backends.Backends {
+		labelsBackend := []string{loadbalancer.ID, loadbalancer.Name, loadbalancer.Zone.String(), backend.ID, backend.Name}
+
+		ch <- prometheus.MustNewConstMetric(c.ServersHealthy, prometheus.GaugeValue, healthy[backend.ID], labelsBackend...)
+		ch <- prometheus.MustNewConstMetric(c.ServersFailing, prometheus.GaugeValue, unhealthy[backend.ID], labelsBackend...)
+	}
+}
+
+func (c *LoadBalancerCollector) CollectFrontendACLs(ctx context.Context, ch chan<- prometheus.Metric, loadbalancer *lb.LB, frontend *lb.Frontend) {
+	response, err := c.lbClient.ListACLs(&lb.ZonedAPIListACLsRequest{Zone: loadbalancer.Zone, FrontendID: frontend.ID}, scw.WithAllPages(), scw.WithContext(ctx))
 
-		ch <- prometheus.MustNewConstMetric(series, prometheus.GaugeValue, value, labels...)
+	if err != nil {
+		c.errors.WithLabelValues("loadbalancer").Add(1)
+		_ = level.Warn(c.logger).Log(
+			"msg", "can't fetch the ACLs of the frontend",
+			"zone", loadbalancer.Zone,
+			"loadbalancerId", loadbalancer.ID,
+			"loadbalancerName", loadbalancer.Name,
+			"frontendId", frontend.ID,
+			"err", err,
+		)
+
+		return
 	}
+
+	labelsFrontend := []string{loadbalancer.ID, loadbalancer.Name, loadbalancer.Zone.String(), frontend.ID, frontend.Name}
+
+	catchAll := map[lb.ACLActionType]float64{
+		lb.ACLActionTypeAllow: 0,
+		lb.ACLActionTypeDeny:  0,
+	}
+
+	for _, acl := range response.ACLs {
+		if acl.Action == nil || acl.Match == nil || acl.Match.Invert || len(acl.Match.HTTPFilterValue) > 0 {
+			continue
+		}
+
+		for _, subnet := range acl.Match.IPSubnet {
+			if subnet != nil && (*subnet == "0.0.0.0/0" || *subnet == "::/0") {
+				catchAll[acl.Action.Type] = 1.0
+			}
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.ACLRules, prometheus.GaugeValue, float64(len(response.ACLs)), labelsFrontend...)
+
+	for action, value := range catchAll {
+		ch <- prometheus.MustNewConstMetric(c.ACLCatchAll, prometheus.GaugeValue, value, append(append([]string{}, labelsFrontend...), action.String())...)
+	}
+}
+
+func (c *LoadBalancerCollector) CollectIPs(ch chan<- prometheus.Metric, loadbalancer *lb.LB, labels []string) {
+	families := map[string]float64{"ipv4": 0, "ipv6": 0}
+
+	for _, ip := range loadbalancer.IP {
+		family := "ipv4"
+
+		if parsed := net.ParseIP(ip.IPAddress); parsed != nil && parsed.To4() == nil {
+			family = "ipv6"
+		}
+
+		families[family]++
+
+		ch <- prometheus.MustNewConstMetric(
+			c.IPInfo,
+			prometheus.GaugeValue,
+			1.0,
+			loadbalancer.ID,
+			loadbalancer.Name,
+			loadbalancer.Zone.String(),
+			ip.ID,
+			ip.IPAddress,
+			family,
+			ip.Reverse,
+		)
+	}
+
+	for family, count := range families {
+		ch <- prometheus.MustNewConstMetric(c.IPs, prometheus.GaugeValue, count, append(append([]string{}, labels...), family)...)
+	}
+}
+
+func (c *LoadBalancerCollector) CollectFrontendRoutes(ctx context.Context, ch chan<- prometheus.Metric, loadbalancer *lb.LB, frontend *lb.Frontend) {
+	response, err := c.lbClient.ListRoutes(&lb.ZonedAPIListRoutesRequest{Zone: loadbalancer.Zone, FrontendID: &frontend.ID}, scw.WithAllPages(), scw.WithContext(ctx))
+
+	if err != nil {
+		c.errors.WithLabelValues("loadbalancer").Add(1)
+		_ = level.Warn(c.logger).Log(
+			"msg", "can't fetch the routes of the frontend",
+			"zone", loadbalancer.Zone,
+			"loadbalancerId", loadbalancer.ID,
+			"loadbalancerName", loadbalancer.Name,
+			"frontendId", frontend.ID,
+			"err", err,
+		)
+
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.FrontendRoutes,
+		prometheus.GaugeValue,
+		float64(len(response.Routes)),
+		loadbalancer.ID,
+		loadbalancer.Name,
+		loadbalancer.Zone.String(),
+		frontend.ID,
+		frontend.Name,
+	)
+}
+
+func (c *LoadBalancerCollector) CollectPrivateNetworks(ctx context.Context, ch chan<- prometheus.Metric, loadbalancer *lb.LB, labels []string) {
+	response, err := c.lbClient.ListLBPrivateNetworks(&lb.ZonedAPIListLBPrivateNetworksRequest{Zone: loadbalancer.Zone, LBID: loadbalancer.ID}, scw.WithAllPages(), scw.WithContext(ctx))
+
+	if err != nil {
+		c.errors.WithLabelValues("loadbalancer").Add(1)
+		_ = level.Warn(c.logger).Log(
+			"msg", "can't fetch the private networks of the loadbalancer",
+			"zone", loadbalancer.Zone,
+			"loadbalancerId", loadbalancer.ID,
+			"loadbalancerName", loadbalancer.Name,
+			"err", err,
+		)
+
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.PrivateNetworks, prometheus.GaugeValue, float64(len(response.PrivateNetwork)), labels...)
+
+	for _, privateNetwork := range response.PrivateNetwork {
+		ch <- prometheus.MustNewConstMetric(
+			c.PrivateNetwork,
+			prometheus.GaugeValue,
+			1.0,
+			loadbalancer.ID,
+			loadbalancer.Name,
+			loadbalancer.Zone.String(),
+			privateNetwork.PrivateNetworkID,
+			privateNetwork.Status.String(),
+		)
+	}
+}
+
+// FetchTimeseries reads the timeseries of the loadbalancer from Cockpit when configured, falling back to the private API.
+func (c *LoadBalancerCollector) FetchTimeseries(ctx context.Context, loadbalancer *lb.LB) ([]*scw.TimeSeries, error) {
+	if c.cockpit != nil {
+		timeseries, err := c.cockpit.Query(ctx, fmt.Sprintf(`{resource_id=%q}`, loadbalancer.ID))
+
+		if err == nil && len(timeseries) > 0 {
+			return timeseries, nil
+		}
+
+		_ = level.Debug(c.logger).Log(
+			"msg", "can't read the loadbalancer metrics from Cockpit, falling back to the private API",
+			"zone", loadbalancer.Zone,
+			"loadbalancerId", loadbalancer.ID,
+			"loadbalancerName", loadbalancer.Name,
+			"err", err,
+		)
+	}
+
+	query := url.Values{}
+
+	query.Add("start_date", time.Now().Add(-1*time.Hour).Format(time.RFC3339))
+	query.Add("end_date", time.Now().Format(time.RFC3339))
+
+	scwReq := &scw.ScalewayRequest{
+		Method:  "GET",
+		Path:    "/lb-private/v1/zones/" + fmt.Sprint(loadbalancer.Zone) + "/lbs/" + fmt.Sprint(loadbalancer.ID) + "/metrics",
+		Query:   query,
+		Headers: http.Header{},
+	}
+
+	var metricResponse LbMetrics
+
+	if err := c.client.Do(scwReq, &metricResponse, scw.WithContext(ctx)); err != nil {
+		return nil, fmt.Errorf("can't fetch the metrics from the private API: %w", err)
+	}
+
+	return metricResponse.Timeseries, nil
 }
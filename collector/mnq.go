@@ -0,0 +1,372 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	mnq "github.com/scaleway/scaleway-sdk-go/api/mnq/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// MNQOptions configures the inspection of the SQS queues.
+type MNQOptions struct {
+	// Credentials are the SQS credentials used to read the queues, by project ID; the projects without one only expose their inventory.
+	Credentials map[string]SQSCredential
+	// HTTPClient sends the requests of the SQS clients, the default client of the AWS SDK is used when nil.
+	HTTPClient *http.Client
+}
+
+// MNQCollector collects metrics about all Messaging and Queuing NATS accounts and SQS queues.
+type MNQCollector struct {
+	logger     log.Logger
+	errors     *prometheus.CounterVec
+	client     *scw.Client
+	natsClient *mnq.NatsAPI
+	sqsClient  *mnq.SqsAPI
+	timeout    time.Duration
+	regions    []scw.Region
+	projects   ProjectFilter
+	options    MNQOptions
+
+	NamespaceInfo          *prometheus.Desc
+	NamespaceCredentials   *prometheus.Desc
+	SQSInfo                *prometheus.Desc
+	SQSCredentials         *prometheus.Desc
+	QueueMessages          *prometheus.Desc
+	QueueMessagesInFlight  *prometheus.Desc
+	QueueMessagesDelayed   *prometheus.Desc
+	QueueLastModifiedTime  *prometheus.Desc
+	QueueVisibilityTimeout *prometheus.Desc
+}
+
+// NewMNQCollector returns a new MNQCollector.
+func NewMNQCollector(logger log.Logger, errors *prometheus.CounterVec, client *scw.Client, timeout time.Duration, regions []scw.Region, projects ProjectFilter, options MNQOptions) *MNQCollector {
+	errors.WithLabelValues("mnq").Add(0)
+
+	_ = level.Info(logger).Log("msg", "MNQ collector enabled")
+
+	labelsNamespace := []string{"id", "name", "region", "project_id", "protocol"}
+
+	labelsSQS := []string{"region", "project_id"}
+
+	labelsQueue := []string{"region", "project_id", "queue"}
+
+	return &MNQCollector{
+		logger:     logger,
+		errors:     errors,
+		client:     client,
+		natsClient: mnq.NewNatsAPI(client),
+		sqsClient:  mnq.NewSqsAPI(client),
+		timeout:    timeout,
+		regions:    regions,
+		projects:   projects,
+		options:    options,
+
+		NamespaceInfo: prometheus.NewDesc(
+			"scaleway_mnq_namespace_info",
+			"A metric with a constant '1' value labeled by the NATS account information",
+			append(append([]string{}, labelsNamespace...), "endpoint"), nil,
+		),
+		NamespaceCredentials: prometheus.NewDesc(
+			"scaleway_mnq_namespace_credentials",
+			"The number of credentials of the NATS account",
+			labelsNamespace, nil,
+		),
+		SQSInfo: prometheus.NewDesc(
+			"scaleway_mnq_sqs_info",
+			"A metric with a constant '1' value labeled by the status and endpoint of the SQS service of the project",
+			append(append([]string{}, labelsSQS...), "status", "endpoint"), nil,
+		),
+		SQSCredentials: prometheus.NewDesc(
+			"scaleway_mnq_sqs_credentials",
+			"The number of SQS credentials of the project",
+			labelsSQS, nil,
+		),
+		QueueMessages: prometheus.NewDesc(
+			"scaleway_mnq_queue_messages",
+			"The approximate number of messages available in the queue",
+			labelsQueue, nil,
+		),
+		QueueMessagesInFlight: prometheus.NewDesc(
+			"scaleway_mnq_queue_messages_in_flight",
+			"The approximate number of messages received but not yet deleted from the queue",
+			labelsQueue, nil,
+		),
+		QueueMessagesDelayed: prometheus.NewDesc(
+			"scaleway_mnq_queue_messages_delayed",
+			"The approximate number of messages delayed in the queue",
+			labelsQueue, nil,
+		),
+		QueueLastModifiedTime: prometheus.NewDesc(
+			"scaleway_mnq_queue_last_modified_timestamp_seconds",
+			"Timestamp of the last modification of the queue attributes",
+			labelsQueue, nil,
+		),
+		QueueVisibilityTimeout: prometheus.NewDesc(
+			"scaleway_mnq_queue_visibility_timeout_seconds",
+			"The visibility timeout of the queue",
+			labelsQueue, nil,
+		),
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+// collected by this Collector.
+func (c *MNQCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.NamespaceInfo
+	ch <- c.NamespaceCredentials
+	ch <- c.SQSInfo
+	ch <- c.SQSCredentials
+	ch <- c.QueueMessages
+	ch <- c.QueueMessagesInFlight
+	ch <- c.QueueMessagesDelayed
+	ch <- c.QueueLastModifiedTime
+	ch <- c.QueueVisibilityTimeout
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *MNQCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for _, region := range c.regions {
+		response, err := c.natsClient.ListNatsAccounts(&mnq.NatsAPIListNatsAccountsRequest{Region: region}, scw.WithAllPages(), scw.WithContext(ctx))
+
+		if err != nil {
+			var responseError *scw.ResponseError
+
+			switch {
+			case errors.As(err, &responseError) && responseError.StatusCode == http.StatusNotImplemented:
+				_ = level.Debug(c.logger).Log("msg", "MNQ is not supported in this region", "region", region)
+			default:
+				c.errors.WithLabelValues("mnq").Add(1)
+				_ = level.Warn(c.logger).Log("msg", "can't fetch the list of NATS accounts", "err", err, "region", region)
+			}
+
+			continue
+		}
+
+		_ = level.Debug(c.logger).Log("msg", fmt.Sprintf("found %d NATS accounts", len(response.NatsAccounts)), "region", region)
+
+		for _, account := range response.NatsAccounts {
+			if !c.projects.Match(account.ProjectID) {
+				continue
+			}
+
+			wg.Add(1)
+
+			go c.FetchNatsAccountMetrics(ctx, &wg, ch, account)
+		}
+
+		wg.Add(1)
+
+		go c.FetchSQSMetrics(ctx, &wg, ch, region)
+	}
+}
+
+func (c *MNQCollector) FetchNatsAccountMetrics(ctx context.Context, parentWg *sync.WaitGroup, ch chan<- prometheus.Metric, account *mnq.NatsAccount) {
+	defer parentWg.Done()
+
+	labelsNamespace := []string{
+		account.ID,
+		account.Name,
+		account.Region.String(),
+		account.ProjectID,
+		"nats",
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.NamespaceInfo, prometheus.GaugeValue, 1.0, append(append([]string{}, labelsNamespace...), account.Endpoint)...)
+
+	credentials, err := c.natsClient.ListNatsCredentials(&mnq.NatsAPIListNatsCredentialsRequest{
+		Region:        account.Region,
+		NatsAccountID: &account.ID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+
+	if err != nil {
+		c.errors.WithLabelValues("mnq").Add(1)
+		_ = level.Warn(c.logger).Log(
+			"msg", "can't fetch the list of credentials for the NATS account",
+			"region", account.Region,
+			"accountId", account.ID,
+			"accountName", account.Name,
+			"err", err,
+		)
+
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.NamespaceCredentials, prometheus.GaugeValue, float64(len(credentials.NatsCredentials)), labelsNamespace...)
+}
+
+// FetchSQSMetrics exposes the SQS service of the projects having SQS credentials, or a configured credential, in the region.
+func (c *MNQCollector) FetchSQSMetrics(ctx context.Context, parentWg *sync.WaitGroup, ch chan<- prometheus.Metric, region scw.Region) {
+	defer parentWg.Done()
+
+	response, err := c.sqsClient.ListSqsCredentials(&mnq.SqsAPIListSqsCredentialsRequest{Region: region}, scw.WithAllPages(), scw.WithContext(ctx))
+
+	if err != nil {
+		c.errors.WithLabelValues("mnq").Add(1)
+		_ = level.Warn(c.logger).Log("msg", "can't fetch the list of SQS credentials", "err", err, "region", region)
+
+		return
+	}
+
+	projects := map[string]int{}
+
+	for _, credential := range response.SqsCredentials {
+		projects[credential.ProjectID]++
+	}
+
+	for projectID := range c.options.Credentials {
+		if _, ok := projects[projectID]; !ok {
+			projects[projectID] = 0
+		}
+	}
+
+	projectIDs := make([]string, 0, len(projects))
+
+	for projectID := range projects {
+		if c.projects.Match(projectID) {
+			projectIDs = append(projectIDs, projectID)
+		}
+	}
+
+	sort.Strings(projectIDs)
+
+	for _, projectID := range projectIDs {
+		info, err := c.sqsClient.GetSqsInfo(&mnq.SqsAPIGetSqsInfoRequest{Region: region, ProjectID: projectID}, scw.WithContext(ctx))
+
+		if err != nil {
+			c.errors.WithLabelValues("mnq").Add(1)
+			_ = level.Warn(c.logger).Log("msg", "can't fetch the SQS status of the project", "err", err, "region", region, "projectId", projectID)
+
+			continue
+		}
+
+		labelsSQS := []string{region.String(), projectID}
+
+		ch <- prometheus.MustNewConstMetric(c.SQSInfo, prometheus.GaugeValue, 1.0, append(append([]string{}, labelsSQS...), info.Status.String(), info.SqsEndpointURL)...)
+		ch <- prometheus.MustNewConstMetric(c.SQSCredentials, prometheus.GaugeValue, float64(projects[projectID]), labelsSQS...)
+
+		credential, ok := c.options.Credentials[projectID]
+
+		if info.Status != mnq.SqsInfoStatusEnabled || !ok {
+			continue
+		}
+
+		c.FetchQueuesMetrics(ctx, ch, region, projectID, info.SqsEndpointURL, credential)
+	}
+}
+
+func (c *MNQCollector) FetchQueuesMetrics(ctx context.Context, ch chan<- prometheus.Metric, region scw.Region, projectID string, endpoint string, credential SQSCredential) {
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+
+	newSession, err := session.NewSession(&aws.Config{
+		Credentials: credentials.NewStaticCredentials(credential.AccessKey, credential.SecretKey, ""),
+		Region:      aws.String(fmt.Sprint(region)),
+		Endpoint:    aws.String(endpoint),
+	})
+
+	if err != nil {
+		c.errors.WithLabelValues("mnq").Add(1)
+		_ = level.Warn(c.logger).Log("msg", "can't create a SQS client", "projectId", projectID, "err", err)
+
+		return
+	}
+
+	sqsConfig := aws.NewConfig()
+
+	if c.options.HTTPClient != nil {
+		sqsConfig.HTTPClient = c.options.HTTPClient
+	}
+
+	sqsClient := sqs.New(newSession, sqsConfig)
+
+	var queueURLs []*string
+
+	err = sqsClient.ListQueuesPagesWithContext(ctx, &sqs.ListQueuesInput{}, func(page *sqs.ListQueuesOutput, _ bool) bool {
+		queueURLs = append(queueURLs, page.QueueUrls...)
+
+		return true
+	})
+
+	if err != nil {
+		c.errors.WithLabelValues("mnq").Add(1)
+		_ = level.Warn(c.logger).Log(
+			"msg", "can't fetch the list of queues for the project",
+			"region", region,
+			"projectId", projectID,
+			"err", err,
+		)
+
+		return
+	}
+
+	for _, queueURL := range queueURLs {
+		attributes, err := sqsClient.GetQueueAttributesWithContext(ctx, &sqs.GetQueueAttributesInput{
+			QueueUrl:       queueURL,
+			AttributeNames: []*string{aws.String(sqs.QueueAttributeNameAll)},
+		})
+
+		if err != nil {
+			c.errors.WithLabelValues("mnq").Add(1)
+			_ = level.Warn(c.logger).Log(
+				"msg", "can't fetch the attributes of the queue",
+				"region", region,
+				"projectId", projectID,
+				"queue", *queueURL,
+				"err", err,
+			)
+
+			continue
+		}
+
+		labelsQueue := []string{
+			region.String(),
+			projectID,
+			path.Base(*queueURL),
+		}
+
+		for name, series := range map[string]*prometheus.Desc{
+			sqs.QueueAttributeNameApproximateNumberOfMessages:           c.QueueMessages,
+			sqs.QueueAttributeNameApproximateNumberOfMessagesNotVisible: c.QueueMessagesInFlight,
+			sqs.QueueAttributeNameApproximateNumberOfMessagesDelayed:    c.QueueMessagesDelayed,
+			sqs.QueueAttributeNameLastModifiedTimestamp:                 c.QueueLastModifiedTime,
+			sqs.QueueAttributeNameVisibilityTimeout:                     c.QueueVisibilityTimeout,
+		} {
+			value, ok := attributes.Attributes[name]
+
+			if !ok || value == nil {
+				continue
+			}
+
+			parsed, err := strconv.ParseFloat(*value, 64)
+
+			if err != nil {
+				continue
+			}
+
+			ch <- prometheus.MustNewConstMetric(series, prometheus.GaugeValue, parsed, labelsQueue...)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package collector
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+var invalidMetricNameCharacters = regexp.MustCompile(`[^a-zA-Z0-9_]`) //nolint:gochecknoglobals // compiled once
+
+// SanitizeMetricName replaces the characters not allowed in a Prometheus metric or label name by an underscore.
+func SanitizeMetricName(name string) string {
+	return invalidMetricNameCharacters.ReplaceAllString(name, "_")
+}
+
+// PassthroughMetrics exposes the timeseries not mapped by a collector as scaleway_<product>_<name> gauges.
+// The first series of a metric fixes its label names, the series carrying other metadata are then skipped
+// as a metric family must keep the same labels.
+type PassthroughMetrics struct {
+	mutex  sync.Mutex
+	labels map[string]string
+}
+
+// NewPassthroughMetrics returns a new PassthroughMetrics.
+func NewPassthroughMetrics() *PassthroughMetrics {
+	return &PassthroughMetrics{labels: map[string]string{}}
+}
+
+// Metric exposes a timeseries, its metadata are added to the given labels and the sample is dated from its latest point with timestamps.
+// It returns false when the timeseries has no point or when its metadata differ from the first series of the metric.
+func (p *PassthroughMetrics) Metric(product string, timeseries *scw.TimeSeries, labelNames []string, labelValues []string, timestamps bool) (prometheus.Metric, bool) {
+	if len(timeseries.Points) == 0 {
+		return nil, false
+	}
+
+	names := append([]string{}, labelNames...)
+	values := append([]string{}, labelValues...)
+
+	known := map[string]bool{}
+
+	for _, name := range labelNames {
+		known[name] = true
+	}
+
+	var keys []string
+
+	for key := range timeseries.Metadata {
+		if !known[SanitizeMetricName(key)] {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		names = append(names, SanitizeMetricName(key))
+		values = append(values, timeseries.Metadata[key])
+	}
+
+	name := "scaleway_" + product + "_" + SanitizeMetricName(timeseries.Name)
+
+	p.mutex.Lock()
+
+	expected, ok := p.labels[name]
+
+	if !ok {
+		expected = strings.Join(names, ",")
+		p.labels[name] = expected
+	}
+
+	p.mutex.Unlock()
+
+	if expected != strings.Join(names, ",") {
+		return nil, false
+	}
+
+	sort.Slice(timeseries.Points, func(i, j int) bool {
+		return timeseries.Points[i].Timestamp.Before(timeseries.Points[j].Timestamp)
+	})
+
+	desc := prometheus.NewDesc(
+		name,
+		"Scaleway's "+timeseries.Name+" metric, exposed as is",
+		names, nil,
+	)
+
+	point := timeseries.Points[len(timeseries.Points)-1]
+
+	metric, err := prometheus.NewConstMetric(desc, prometheus.GaugeValue, float64(point.Value), values...)
+	if err != nil {
+		return nil, false
+	}
+
+	return withPointTimestamp(metric, point, timestamps), true
+}
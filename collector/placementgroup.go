@@ -0,0 +1,171 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// PlacementGroupCollector collects metrics about all instance placement groups.
+type PlacementGroupCollector struct {
+	logger         log.Logger
+	errors         *prometheus.CounterVec
+	client         *scw.Client
+	instanceClient *instance.API
+	timeout        time.Duration
+	zones          []scw.Zone
+	projects       ProjectFilter
+	tags           TagFilter
+	tagLabels      TagLabels
+
+	PolicyRespected       *prometheus.Desc
+	Servers               *prometheus.Desc
+	ServerPolicyRespected *prometheus.Desc
+}
+
+// NewPlacementGroupCollector returns a new PlacementGroupCollector.
+func NewPlacementGroupCollector(logger log.Logger, errors *prometheus.CounterVec, client *scw.Client, timeout time.Duration, zones []scw.Zone, projects ProjectFilter, tags TagFilter, tagLabels TagLabels) *PlacementGroupCollector {
+	errors.WithLabelValues("placementgroup").Add(0)
+
+	_ = level.Info(logger).Log("msg", "Placement group collector enabled")
+
+	labels := []string{"id", "name", "zone", "project_id"}
+
+	return &PlacementGroupCollector{
+		logger:         logger,
+		errors:         errors,
+		client:         client,
+		instanceClient: instance.NewAPI(client),
+		timeout:        timeout,
+		zones:          zones,
+		projects:       projects,
+		tags:           tags,
+		tagLabels:      tagLabels,
+
+		PolicyRespected: prometheus.NewDesc(
+			"scaleway_placement_group_policy_respected",
+			"If 1 the policy of the placement group is respected, 0 otherwise",
+			append(append([]string{}, labels...), append([]string{"policy_mode", "policy_type"}, tagLabels.Names()...)...), nil,
+		),
+		Servers: prometheus.NewDesc(
+			"scaleway_placement_group_servers",
+			"The number of servers member of the placement group",
+			labels, nil,
+		),
+		ServerPolicyRespected: prometheus.NewDesc(
+			"scaleway_placement_group_server_policy_respected",
+			"If 1 the policy of the placement group is respected for the server, 0 otherwise",
+			[]string{"placement_group_id", "placement_group_name", "zone", "id", "name"}, nil,
+		),
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+// collected by this Collector.
+func (c *PlacementGroupCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.PolicyRespected
+	ch <- c.Servers
+	ch <- c.ServerPolicyRespected
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *PlacementGroupCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for _, zone := range c.zones {
+		response, err := c.instanceClient.ListPlacementGroups(&instance.ListPlacementGroupsRequest{Zone: zone}, scw.WithAllPages(), scw.WithContext(ctx))
+
+		if err != nil {
+			var responseError *scw.ResponseError
+
+			switch {
+			case errors.As(err, &responseError) && responseError.StatusCode == http.StatusNotImplemented:
+				_ = level.Debug(c.logger).Log("msg", "Instance is not supported in this zone", "zone", zone)
+			default:
+				c.errors.WithLabelValues("placementgroup").Add(1)
+				_ = level.Warn(c.logger).Log("msg", "can't fetch the list of placement groups", "err", err, "zone", zone)
+			}
+
+			continue
+		}
+
+		_ = level.Debug(c.logger).Log("msg", fmt.Sprintf("found %d placement groups", len(response.PlacementGroups)), "zone", zone)
+
+		for _, placementGroup := range response.PlacementGroups {
+			if !c.projects.Match(placementGroup.Project) || !c.tags.Match(placementGroup.Tags) {
+				continue
+			}
+
+			wg.Add(1)
+
+			go c.FetchPlacementGroupMetrics(ctx, &wg, ch, placementGroup)
+		}
+	}
+}
+
+func (c *PlacementGroupCollector) FetchPlacementGroupMetrics(ctx context.Context, parentWg *sync.WaitGroup, ch chan<- prometheus.Metric, placementGroup *instance.PlacementGroup) {
+	defer parentWg.Done()
+
+	labels := []string{placementGroup.ID, placementGroup.Name, placementGroup.Zone.String(), placementGroup.Project}
+
+	var respected float64
+
+	if placementGroup.PolicyRespected {
+		respected = 1.0
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.PolicyRespected,
+		prometheus.GaugeValue,
+		respected,
+		append(append([]string{}, labels...), append([]string{placementGroup.PolicyMode.String(), placementGroup.PolicyType.String()}, c.tagLabels.Values(placementGroup.Tags)...)...)...,
+	)
+
+	servers, err := c.instanceClient.GetPlacementGroupServers(&instance.GetPlacementGroupServersRequest{
+		Zone:             placementGroup.Zone,
+		PlacementGroupID: placementGroup.ID,
+	}, scw.WithContext(ctx))
+
+	if err != nil {
+		c.errors.WithLabelValues("placementgroup").Add(1)
+		_ = level.Warn(c.logger).Log(
+			"msg", "can't fetch the servers of the placement group",
+			"zone", placementGroup.Zone,
+			"placementGroupId", placementGroup.ID,
+			"placementGroupName", placementGroup.Name,
+			"err", err,
+		)
+
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.Servers, prometheus.GaugeValue, float64(len(servers.Servers)), labels...)
+
+	for _, server := range servers.Servers {
+		var serverRespected float64
+
+		if server.PolicyRespected {
+			serverRespected = 1.0
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.ServerPolicyRespected,
+			prometheus.GaugeValue,
+			serverRespected,
+			placementGroup.ID, placementGroup.Name, placementGroup.Zone.String(), server.ID, server.Name,
+		)
+	}
+}
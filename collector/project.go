@@ -0,0 +1,189 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	account "github.com/scaleway/scaleway-sdk-go/api/account/v2"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/lb/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// ProjectCollector collects the projects of the organizations along with the number of resources they hold.
+type ProjectCollector struct {
+	logger          log.Logger
+	errors          *prometheus.CounterVec
+	client          *scw.Client
+	accountClient   *account.API
+	instanceClient  *instance.API
+	lbClient        *lb.ZonedAPI
+	k8sClient       *k8s.API
+	rdbClient       *rdb.API
+	timeout         time.Duration
+	regions         []scw.Region
+	zones           []scw.Zone
+	projects        ProjectFilter
+	organizationIDs []string
+
+	Info      *prometheus.Desc
+	Resources *prometheus.Desc
+}
+
+// NewProjectCollector returns a new ProjectCollector.
+func NewProjectCollector(
+	logger log.Logger,
+	errors *prometheus.CounterVec,
+	client *scw.Client,
+	timeout time.Duration,
+	regions []scw.Region,
+	zones []scw.Zone,
+	projects ProjectFilter,
+	organizationIDs []string,
+) *ProjectCollector {
+	errors.WithLabelValues("project").Add(0)
+
+	_ = level.Info(logger).Log("msg", "Project collector enabled")
+
+	return &ProjectCollector{
+		logger:          logger,
+		errors:          errors,
+		client:          client,
+		accountClient:   account.NewAPI(client),
+		instanceClient:  instance.NewAPI(client),
+		lbClient:        lb.NewZonedAPI(client),
+		k8sClient:       k8s.NewAPI(client),
+		rdbClient:       rdb.NewAPI(client),
+		timeout:         timeout,
+		regions:         regions,
+		zones:           zones,
+		projects:        projects,
+		organizationIDs: organizationIDs,
+
+		Info: prometheus.NewDesc(
+			"scaleway_project_info",
+			"A metric with a constant '1' value labeled by the project information",
+			[]string{"organization_id", "id", "name", "description"}, nil,
+		),
+		Resources: prometheus.NewDesc(
+			"scaleway_project_resources",
+			"The number of resources of the project over all the scraped regions and zones, per resource type",
+			[]string{"organization_id", "id", "name", "resource"}, nil,
+		),
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+// collected by this Collector.
+func (c *ProjectCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Info
+	ch <- c.Resources
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *ProjectCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for _, organizationID := range c.organizationIDs {
+		c.CollectProjects(ctx, &wg, ch, organizationID)
+	}
+}
+
+func (c *ProjectCollector) CollectProjects(ctx context.Context, wg *sync.WaitGroup, ch chan<- prometheus.Metric, organizationID string) {
+	response, err := c.accountClient.ListProjects(&account.ListProjectsRequest{OrganizationID: organizationID}, scw.WithAllPages(), scw.WithContext(ctx))
+
+	if err != nil {
+		c.errors.WithLabelValues("project").Add(1)
+		_ = level.Warn(c.logger).Log("msg", "can't fetch the list of projects", "err", err, "organization_id", organizationID)
+
+		return
+	}
+
+	_ = level.Debug(c.logger).Log("msg", fmt.Sprintf("found %d projects", len(response.Projects)), "organization_id", organizationID)
+
+	for _, project := range response.Projects {
+		if !c.projects.Match(project.ID) {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.Info, prometheus.GaugeValue, 1.0, organizationID, project.ID, project.Name, project.Description)
+
+		wg.Add(1)
+
+		go c.FetchProjectResources(ctx, wg, ch, project)
+	}
+}
+
+func (c *ProjectCollector) FetchProjectResources(ctx context.Context, parentWg *sync.WaitGroup, ch chan<- prometheus.Metric, project *account.Project) {
+	defer parentWg.Done()
+
+	resources := map[string]float64{
+		"servers":         0,
+		"volumes":         0,
+		"snapshots":       0,
+		"images":          0,
+		"ips":             0,
+		"security_groups": 0,
+		"load_balancers":  0,
+		"k8s_clusters":    0,
+		"databases":       0,
+	}
+
+	for _, zone := range c.zones {
+		dashboard, err := c.instanceClient.GetDashboard(&instance.GetDashboardRequest{Zone: zone, Project: &project.ID}, scw.WithContext(ctx))
+
+		if err != nil {
+			c.errors.WithLabelValues("project").Add(1)
+			_ = level.Warn(c.logger).Log("msg", "can't fetch the instance dashboard of the project", "err", err, "zone", zone, "projectId", project.ID)
+		} else {
+			resources["servers"] += float64(dashboard.Dashboard.ServersCount)
+			resources["volumes"] += float64(dashboard.Dashboard.VolumesCount)
+			resources["snapshots"] += float64(dashboard.Dashboard.SnapshotsCount)
+			resources["images"] += float64(dashboard.Dashboard.ImagesCount)
+			resources["ips"] += float64(dashboard.Dashboard.IPsCount)
+			resources["security_groups"] += float64(dashboard.Dashboard.SecurityGroupsCount)
+		}
+
+		lbs, err := c.lbClient.ListLBs(&lb.ZonedAPIListLBsRequest{Zone: zone, ProjectID: &project.ID}, scw.WithAllPages(), scw.WithContext(ctx))
+
+		if err != nil {
+			// the load balancers are not available in every zone
+			_ = level.Debug(c.logger).Log("msg", "can't fetch the list of load balancers of the project", "err", err, "zone", zone, "projectId", project.ID)
+		} else {
+			resources["load_balancers"] += float64(len(lbs.LBs))
+		}
+	}
+
+	for _, region := range c.regions {
+		clusters, err := c.k8sClient.ListClusters(&k8s.ListClustersRequest{Region: region, ProjectID: &project.ID}, scw.WithAllPages(), scw.WithContext(ctx))
+
+		if err != nil {
+			_ = level.Debug(c.logger).Log("msg", "can't fetch the list of kubernetes clusters of the project", "err", err, "region", region, "projectId", project.ID)
+		} else {
+			resources["k8s_clusters"] += float64(len(clusters.Clusters))
+		}
+
+		databases, err := c.rdbClient.ListInstances(&rdb.ListInstancesRequest{Region: region, ProjectID: &project.ID}, scw.WithAllPages(), scw.WithContext(ctx))
+
+		if err != nil {
+			_ = level.Debug(c.logger).Log("msg", "can't fetch the list of databases of the project", "err", err, "region", region, "projectId", project.ID)
+		} else {
+			resources["databases"] += float64(len(databases.Instances))
+		}
+	}
+
+	for resource, count := range resources {
+		ch <- prometheus.MustNewConstMetric(c.Resources, prometheus.GaugeValue, count, project.OrganizationID, project.ID, project.Name, resource)
+	}
+}
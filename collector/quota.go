@@ -0,0 +1,152 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/lb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// QuotaCollector collects the quotas of the organizations along with the current usage of the quoted resources.
+type QuotaCollector struct {
+	logger          log.Logger
+	errors          *prometheus.CounterVec
+	iamClient       *iam.API
+	instanceClient  *instance.API
+	lbClient        *lb.ZonedAPI
+	timeout         time.Duration
+	zones           []scw.Zone
+	organizationIDs []string
+
+	Limit *prometheus.Desc
+	Usage *prometheus.Desc
+}
+
+// NewQuotaCollector returns a new QuotaCollector.
+func NewQuotaCollector(logger log.Logger, errors *prometheus.CounterVec, client *scw.Client, timeout time.Duration, zones []scw.Zone, organizationIDs []string) *QuotaCollector {
+	errors.WithLabelValues("quota").Add(0)
+
+	_ = level.Info(logger).Log("msg", "Quota collector enabled")
+
+	labels := []string{"organization_id", "resource"}
+
+	return &QuotaCollector{
+		logger:          logger,
+		errors:          errors,
+		iamClient:       iam.NewAPI(client),
+		instanceClient:  instance.NewAPI(client),
+		lbClient:        lb.NewZonedAPI(client),
+		timeout:         timeout,
+		zones:           zones,
+		organizationIDs: organizationIDs,
+
+		Limit: prometheus.NewDesc(
+			"scaleway_quota_limit",
+			"The quota of the organization for the resource, unlimited resources are not exposed",
+			labels, nil,
+		),
+		Usage: prometheus.NewDesc(
+			"scaleway_quota_usage",
+			"The current usage of the resource over all the scraped zones, the resource label matches the quota name",
+			labels, nil,
+		),
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+// collected by this Collector.
+func (c *QuotaCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Limit
+	ch <- c.Usage
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *QuotaCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for _, organizationID := range c.organizationIDs {
+		wg.Add(2)
+
+		go c.CollectLimits(ctx, &wg, ch, organizationID)
+		go c.CollectUsage(ctx, &wg, ch, organizationID)
+	}
+}
+
+func (c *QuotaCollector) CollectLimits(ctx context.Context, parentWg *sync.WaitGroup, ch chan<- prometheus.Metric, organizationID string) {
+	defer parentWg.Done()
+
+	response, err := c.iamClient.ListQuota(&iam.ListQuotaRequest{OrganizationID: organizationID}, scw.WithAllPages(), scw.WithContext(ctx))
+
+	if err != nil {
+		c.errors.WithLabelValues("quota").Add(1)
+		_ = level.Warn(c.logger).Log("msg", "can't fetch the list of quotas", "err", err, "organization_id", organizationID)
+
+		return
+	}
+
+	_ = level.Debug(c.logger).Log("msg", fmt.Sprintf("found %d quotas", len(response.Quota)), "organization_id", organizationID)
+
+	for _, quotum := range response.Quota {
+		if (quotum.Unlimited != nil && *quotum.Unlimited) || quotum.Limit == nil {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.Limit, prometheus.GaugeValue, float64(*quotum.Limit), organizationID, quotum.Name)
+	}
+}
+
+func (c *QuotaCollector) CollectUsage(ctx context.Context, parentWg *sync.WaitGroup, ch chan<- prometheus.Metric, organizationID string) {
+	defer parentWg.Done()
+
+	usage := map[string]float64{}
+
+	for _, zone := range c.zones {
+		dashboard, err := c.instanceClient.GetDashboard(&instance.GetDashboardRequest{Zone: zone, Organization: &organizationID}, scw.WithContext(ctx))
+
+		if err != nil {
+			c.errors.WithLabelValues("quota").Add(1)
+			_ = level.Warn(c.logger).Log("msg", "can't fetch the instance dashboard", "err", err, "zone", zone, "organization_id", organizationID)
+		} else {
+			usage["servers"] += float64(dashboard.Dashboard.ServersCount)
+			usage["volumes"] += float64(dashboard.Dashboard.VolumesCount)
+			usage["snapshots"] += float64(dashboard.Dashboard.SnapshotsCount)
+			usage["images"] += float64(dashboard.Dashboard.ImagesCount)
+			usage["instance_ips"] += float64(dashboard.Dashboard.IPsCount)
+			usage["security_groups"] += float64(dashboard.Dashboard.SecurityGroupsCount)
+			usage["placement_groups"] += float64(dashboard.Dashboard.PlacementGroupsCount)
+			usage["private_nics"] += float64(dashboard.Dashboard.PrivateNicsCount)
+
+			for commercialType, count := range dashboard.Dashboard.ServersByTypes {
+				usage["servers_type_"+strings.ToUpper(commercialType)] += float64(count)
+			}
+		}
+
+		lbs, err := c.lbClient.ListLBs(&lb.ZonedAPIListLBsRequest{Zone: zone, OrganizationID: &organizationID}, scw.WithAllPages(), scw.WithContext(ctx))
+
+		if err != nil {
+			// the load balancers are not available in every zone
+			_ = level.Debug(c.logger).Log("msg", "can't fetch the list of load balancers", "err", err, "zone", zone)
+
+			continue
+		}
+
+		usage["lbs"] += float64(len(lbs.LBs))
+	}
+
+	for resource, value := range usage {
+		ch <- prometheus.MustNewConstMetric(c.Usage, prometheus.GaugeValue, value, organizationID, resource)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"net/http"
 	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -24,19 +26,36 @@ type RedisCollector struct {
 	redisClient *redis.API
 	timeout     time.Duration
 	zones       []scw.Zone
+	projects    ProjectFilter
+	tags        TagFilter
+	cache       *ListingCache
+	unmapped    bool
+	passthrough *PassthroughMetrics
+	timestamps  bool
+	tagLabels   TagLabels
 
+	Info                 *prometheus.Desc
+	Nodes                *prometheus.Desc
+	UpgradeAvailable     *prometheus.Desc
+	EndOfLife            *prometheus.Desc
+	Endpoint             *prometheus.Desc
+	TLSEnabled           *prometheus.Desc
+	ACLRules             *prometheus.Desc
+	ACLOpenToWorld       *prometheus.Desc
+	NodeMemory           *prometheus.Desc
+	Memory               *prometheus.Desc
 	CPUUsagePercent      *prometheus.Desc
 	MemUsagePercent      *prometheus.Desc
 	DBMemoryUsagePercent *prometheus.Desc
 }
 
 // NewRedisCollector returns a new RedisCollector.
-func NewRedisCollector(logger log.Logger, errors *prometheus.CounterVec, client *scw.Client, timeout time.Duration, zones []scw.Zone) *RedisCollector {
+func NewRedisCollector(logger log.Logger, errors *prometheus.CounterVec, client *scw.Client, timeout time.Duration, zones []scw.Zone, projects ProjectFilter, tags TagFilter, cache *ListingCache, unmapped bool, timestamps bool, tagLabels TagLabels) *RedisCollector {
 	errors.WithLabelValues("redis").Add(0)
 
 	_ = level.Info(logger).Log("msg", "Redis collector enabled")
 
-	labels := []string{"id", "name", "node"}
+	labels := []string{"id", "name", "zone", "project_id", "node"}
 
 	return &RedisCollector{
 		logger:      logger,
@@ -45,7 +64,64 @@ func NewRedisCollector(logger log.Logger, errors *prometheus.CounterVec, client
 		redisClient: redis.NewAPI(client),
 		timeout:     timeout,
 		zones:       zones,
+		projects:    projects,
+		tags:        tags,
+		cache:       cache,
+		unmapped:    unmapped,
+		passthrough: NewPassthroughMetrics(),
+		timestamps:  timestamps,
+		tagLabels:   tagLabels,
 
+		Info: prometheus.NewDesc(
+			"scaleway_redis_info",
+			"The redis cluster information",
+			append([]string{"id", "name", "zone", "project_id", "tags", "node_type", "version", "mode"}, tagLabels.Names()...), nil,
+		),
+		Nodes: prometheus.NewDesc(
+			"scaleway_redis_nodes",
+			"The number of nodes of the redis cluster",
+			[]string{"id", "name"}, nil,
+		),
+		UpgradeAvailable: prometheus.NewDesc(
+			"scaleway_redis_upgrade_available",
+			"If 1 a newer redis version is available for the cluster, 0 otherwise",
+			[]string{"id", "name", "version"}, nil,
+		),
+		EndOfLife: prometheus.NewDesc(
+			"scaleway_redis_version_end_of_life_timestamp_seconds",
+			"The end of life of the redis version running the cluster",
+			[]string{"id", "name", "version"}, nil,
+		),
+		Endpoint: prometheus.NewDesc(
+			"scaleway_redis_endpoint_info",
+			"The redis cluster endpoint information, the network is public or private",
+			[]string{"id", "name", "endpoint_id", "network", "private_network_id", "port"}, nil,
+		),
+		TLSEnabled: prometheus.NewDesc(
+			"scaleway_redis_tls_enabled",
+			"If 1 the connections to the redis cluster are encrypted with TLS, 0 otherwise",
+			[]string{"id", "name"}, nil,
+		),
+		ACLRules: prometheus.NewDesc(
+			"scaleway_redis_acl_rules",
+			"The number of ACL rules of the redis cluster",
+			[]string{"id", "name"}, nil,
+		),
+		ACLOpenToWorld: prometheus.NewDesc(
+			"scaleway_redis_acl_open_to_world",
+			"If 1 an ACL rule of the redis cluster allows any IP (0.0.0.0/0), 0 otherwise",
+			[]string{"id", "name"}, nil,
+		),
+		NodeMemory: prometheus.NewDesc(
+			"scaleway_redis_node_memory_bytes",
+			"The memory of each node of the redis cluster, from its node type",
+			[]string{"id", "name"}, nil,
+		),
+		Memory: prometheus.NewDesc(
+			"scaleway_redis_memory_bytes",
+			"The total memory of the nodes of the redis cluster",
+			[]string{"id", "name"}, nil,
+		),
 		CPUUsagePercent: prometheus.NewDesc(
 			"scaleway_redis_cpu_usage_percent",
 			"The redis node CPU usage percentage",
@@ -67,6 +143,16 @@ func NewRedisCollector(logger log.Logger, errors *prometheus.CounterVec, client
 // Describe sends the super-set of all possible descriptors of metrics
 // collected by this Collector.
 func (c *RedisCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Info
+	ch <- c.Nodes
+	ch <- c.UpgradeAvailable
+	ch <- c.EndOfLife
+	ch <- c.Endpoint
+	ch <- c.TLSEnabled
+	ch <- c.ACLRules
+	ch <- c.ACLOpenToWorld
+	ch <- c.NodeMemory
+	ch <- c.Memory
 	ch <- c.CPUUsagePercent
 	ch <- c.MemUsagePercent
 	ch <- c.DBMemoryUsagePercent
@@ -74,11 +160,13 @@ func (c *RedisCollector) Describe(ch chan<- *prometheus.Desc) {
 
 // Collect is called by the Prometheus registry when collecting metrics.
 func (c *RedisCollector) Collect(ch chan<- prometheus.Metric) {
-	_, cancel := context.WithTimeout(context.Background(), c.timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
 	defer cancel()
 
 	for _, zone := range c.zones {
-		clusterList, err := c.redisClient.ListClusters(&redis.ListClustersRequest{Zone: zone})
+		clusterList, err := CachedListing(c.cache, "redis/"+zone.String(), func() (*redis.ListClustersResponse, error) {
+			return c.redisClient.ListClusters(&redis.ListClustersRequest{Zone: zone}, scw.WithAllPages(), scw.WithContext(ctx))
+		})
 
 		if err != nil {
 			var responseError *scw.ResponseError
@@ -95,26 +183,91 @@ func (c *RedisCollector) Collect(ch chan<- prometheus.Metric) {
 			}
 		}
 
+		versions, err := c.redisClient.ListClusterVersions(&redis.ListClusterVersionsRequest{Zone: zone}, scw.WithAllPages(), scw.WithContext(ctx))
+
+		if err != nil {
+			c.errors.WithLabelValues("redis").Add(1)
+			_ = level.Warn(c.logger).Log("msg", "can't fetch the list of redis versions", "err", err, "zone", zone)
+		}
+
+		nodeTypes := map[string]*redis.NodeType{}
+
+		nodeTypeList, err := c.redisClient.ListNodeTypes(&redis.ListNodeTypesRequest{Zone: zone, IncludeDisabledTypes: true}, scw.WithAllPages(), scw.WithContext(ctx))
+
+		if err != nil {
+			c.errors.WithLabelValues("redis").Add(1)
+			_ = level.Warn(c.logger).Log("msg", "can't fetch the list of redis node types", "err", err, "zone", zone)
+		} else {
+			for _, nodeType := range nodeTypeList.NodeTypes {
+				nodeTypes[nodeType.Name] = nodeType
+			}
+		}
+
 		var wg sync.WaitGroup
 		defer wg.Wait()
 
 		for _, cluster := range clusterList.Clusters {
+			if !c.projects.Match(cluster.ProjectID) || !c.tags.Match(cluster.Tags) {
+				continue
+			}
+
 			wg.Add(1)
 
 			_ = level.Debug(c.logger).Log("msg", fmt.Sprintf("Fetching metrics for cluster : %s", cluster.ID), "zone", zone)
 
-			go c.FetchRedisMetrics(&wg, ch, zone, cluster)
+			go c.FetchRedisMetrics(ctx, &wg, ch, zone, cluster, versions, nodeTypes[cluster.NodeType])
 		}
 	}
 }
 
-func (c *RedisCollector) FetchRedisMetrics(parentWg *sync.WaitGroup, ch chan<- prometheus.Metric, zone scw.Zone, cluster *redis.Cluster) {
+func (c *RedisCollector) FetchRedisMetrics(ctx context.Context, parentWg *sync.WaitGroup, ch chan<- prometheus.Metric, zone scw.Zone, cluster *redis.Cluster, versions *redis.ListClusterVersionsResponse, nodeType *redis.NodeType) {
 	defer parentWg.Done()
 
+	tags := append([]string{}, cluster.Tags...)
+
+	sort.Strings(tags)
+
+	// a single node is standalone, two nodes are a primary and its replica, cluster mode starts at three nodes
+	mode := "standalone"
+
+	switch {
+	case cluster.ClusterSize >= 3:
+		mode = "cluster"
+	case cluster.ClusterSize == 2:
+		mode = "high_availability"
+	}
+
+	labelsInfo := []string{
+		cluster.ID,
+		cluster.Name,
+		zone.String(),
+		cluster.ProjectID,
+		strings.Join(tags, ","),
+		cluster.NodeType,
+		cluster.Version,
+		mode,
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.Info, prometheus.GaugeValue, 1.0, append(labelsInfo, c.tagLabels.Values(cluster.Tags)...)...)
+
+	ch <- prometheus.MustNewConstMetric(c.Nodes, prometheus.GaugeValue, float64(cluster.ClusterSize), cluster.ID, cluster.Name)
+
+	if versions != nil {
+		c.CollectVersion(ch, cluster, versions.Versions)
+	}
+
+	c.CollectEndpoints(ch, cluster)
+	c.CollectACLRules(ch, cluster)
+
+	if nodeType != nil {
+		ch <- prometheus.MustNewConstMetric(c.NodeMemory, prometheus.GaugeValue, float64(nodeType.Memory), cluster.ID, cluster.Name)
+		ch <- prometheus.MustNewConstMetric(c.Memory, prometheus.GaugeValue, float64(nodeType.Memory)*float64(cluster.ClusterSize), cluster.ID, cluster.Name)
+	}
+
 	metricResponse, err := c.redisClient.GetClusterMetrics(&redis.GetClusterMetricsRequest{
 		Zone:      zone,
 		ClusterID: cluster.ID,
-	})
+	}, scw.WithContext(ctx))
 
 	if err != nil {
 		c.errors.WithLabelValues("redis").Add(1)
@@ -133,6 +286,8 @@ func (c *RedisCollector) FetchRedisMetrics(parentWg *sync.WaitGroup, ch chan<- p
 		labels := []string{
 			cluster.ID,
 			cluster.Name,
+			zone.String(),
+			cluster.ProjectID,
 			timeseries.Metadata["node"],
 		}
 
@@ -146,6 +301,14 @@ func (c *RedisCollector) FetchRedisMetrics(parentWg *sync.WaitGroup, ch chan<- p
 		case "db_memory_usage_percent":
 			series = c.DBMemoryUsagePercent
 		default:
+			if c.unmapped {
+				if metric, ok := c.passthrough.Metric("redis", timeseries, []string{"id", "name", "zone", "project_id"}, []string{cluster.ID, cluster.Name, zone.String(), cluster.ProjectID}, c.timestamps); ok {
+					ch <- metric
+				}
+
+				continue
+			}
+
 			_ = level.Debug(c.logger).Log(
 				"msg", "unmapped scaleway metric",
 				"scwMetric", timeseries.Name,
@@ -175,8 +338,99 @@ func (c *RedisCollector) FetchRedisMetrics(parentWg *sync.WaitGroup, ch chan<- p
 			return timeseries.Points[i].Timestamp.Before(timeseries.Points[j].Timestamp)
 		})
 
-		value := float64(timeseries.Points[len(timeseries.Points)-1].Value)
+		point := timeseries.Points[len(timeseries.Points)-1]
 
-		ch <- prometheus.MustNewConstMetric(series, prometheus.GaugeValue, value, labels...)
+		ch <- withPointTimestamp(prometheus.MustNewConstMetric(series, prometheus.GaugeValue, float64(point.Value), labels...), point, c.timestamps)
 	}
 }
+
+func (c *RedisCollector) CollectVersion(ch chan<- prometheus.Metric, cluster *redis.Cluster, versions []*redis.ClusterVersion) {
+	var upgradeAvailable float64
+
+	for _, version := range versions {
+		if CompareVersions(version.Version, cluster.Version) > 0 {
+			upgradeAvailable = 1.0
+		}
+
+		if version.Version == cluster.Version && version.EndOfLifeAt != nil {
+			ch <- prometheus.MustNewConstMetric(c.EndOfLife, prometheus.GaugeValue, float64(version.EndOfLifeAt.Unix()), cluster.ID, cluster.Name, cluster.Version)
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.UpgradeAvailable, prometheus.GaugeValue, upgradeAvailable, cluster.ID, cluster.Name, cluster.Version)
+}
+
+func (c *RedisCollector) CollectEndpoints(ch chan<- prometheus.Metric, cluster *redis.Cluster) {
+	var tlsEnabled float64
+
+	if cluster.TLSEnabled {
+		tlsEnabled = 1.0
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.TLSEnabled, prometheus.GaugeValue, tlsEnabled, cluster.ID, cluster.Name)
+
+	for _, endpoint := range cluster.Endpoints {
+		network := "public"
+
+		var privateNetworkID string
+
+		if endpoint.PrivateNetwork != nil {
+			network = "private"
+			privateNetworkID = endpoint.PrivateNetwork.ID
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.Endpoint,
+			prometheus.GaugeValue,
+			1.0,
+			cluster.ID,
+			cluster.Name,
+			endpoint.ID,
+			network,
+			privateNetworkID,
+			fmt.Sprint(endpoint.Port),
+		)
+	}
+}
+
+func (c *RedisCollector) CollectACLRules(ch chan<- prometheus.Metric, cluster *redis.Cluster) {
+	var openToWorld float64
+
+	for _, rule := range cluster.ACLRules {
+		if rule.IPCidr == nil || rule.IPCidr.IP == nil {
+			continue
+		}
+
+		if ones, _ := rule.IPCidr.Mask.Size(); ones == 0 {
+			openToWorld = 1.0
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.ACLRules, prometheus.GaugeValue, float64(len(cluster.ACLRules)), cluster.ID, cluster.Name)
+	ch <- prometheus.MustNewConstMetric(c.ACLOpenToWorld, prometheus.GaugeValue, openToWorld, cluster.ID, cluster.Name)
+}
+
+// CompareVersions compares two dotted versions (e.g. 7.0.5) number by number,
+// it returns a positive number when a is newer than b, a negative one when older and 0 when equal.
+func CompareVersions(a string, b string) int {
+	partsA := strings.Split(a, ".")
+	partsB := strings.Split(b, ".")
+
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		var numberA, numberB int
+
+		if i < len(partsA) {
+			numberA, _ = strconv.Atoi(partsA[i])
+		}
+
+		if i < len(partsB) {
+			numberB, _ = strconv.Atoi(partsB[i])
+		}
+
+		if numberA != numberB {
+			return numberA - numberB
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,181 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/scaleway/scaleway-sdk-go/api/registry/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// RegistryCollector collects metrics about all container registry namespaces.
+type RegistryCollector struct {
+	logger         log.Logger
+	errors         *prometheus.CounterVec
+	client         *scw.Client
+	registryClient *registry.API
+	timeout        time.Duration
+	regions        []scw.Region
+	projects       ProjectFilter
+
+	NamespaceSize   *prometheus.Desc
+	NamespaceImages *prometheus.Desc
+	ImageSize       *prometheus.Desc
+	ImageTags       *prometheus.Desc
+}
+
+// NewRegistryCollector returns a new RegistryCollector.
+func NewRegistryCollector(logger log.Logger, errors *prometheus.CounterVec, client *scw.Client, timeout time.Duration, regions []scw.Region, projects ProjectFilter) *RegistryCollector {
+	errors.WithLabelValues("registry").Add(0)
+
+	_ = level.Info(logger).Log("msg", "Registry collector enabled")
+
+	labelsNamespace := []string{"id", "name", "region", "project_id", "public"}
+
+	labelsImage := []string{"namespace", "id", "name", "region", "project_id", "visibility"}
+
+	return &RegistryCollector{
+		logger:         logger,
+		errors:         errors,
+		client:         client,
+		registryClient: registry.NewAPI(client),
+		timeout:        timeout,
+		regions:        regions,
+		projects:       projects,
+
+		NamespaceSize: prometheus.NewDesc(
+			"scaleway_registry_namespace_size_bytes",
+			"The storage used by the images of the registry namespace",
+			labelsNamespace, nil,
+		),
+		NamespaceImages: prometheus.NewDesc(
+			"scaleway_registry_namespace_images",
+			"The number of images in the registry namespace",
+			labelsNamespace, nil,
+		),
+		ImageSize: prometheus.NewDesc(
+			"scaleway_registry_image_size_bytes",
+			"The storage used by the image",
+			labelsImage, nil,
+		),
+		ImageTags: prometheus.NewDesc(
+			"scaleway_registry_image_tags",
+			"The number of tags of the image",
+			labelsImage, nil,
+		),
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+// collected by this Collector.
+func (c *RegistryCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.NamespaceSize
+	ch <- c.NamespaceImages
+	ch <- c.ImageSize
+	ch <- c.ImageTags
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *RegistryCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for _, region := range c.regions {
+		response, err := c.registryClient.ListNamespaces(&registry.ListNamespacesRequest{Region: region}, scw.WithAllPages(), scw.WithContext(ctx))
+
+		if err != nil {
+			var responseError *scw.ResponseError
+
+			switch {
+			case errors.As(err, &responseError) && responseError.StatusCode == http.StatusNotImplemented:
+				_ = level.Debug(c.logger).Log("msg", "Registry is not supported in this region", "region", region)
+			default:
+				c.errors.WithLabelValues("registry").Add(1)
+				_ = level.Warn(c.logger).Log("msg", "can't fetch the list of registry namespaces", "err", err, "region", region)
+			}
+
+			continue
+		}
+
+		_ = level.Debug(c.logger).Log("msg", fmt.Sprintf("found %d registry namespaces", len(response.Namespaces)), "region", region)
+
+		for _, namespace := range response.Namespaces {
+			if !c.projects.Match(namespace.ProjectID) {
+				continue
+			}
+
+			wg.Add(1)
+
+			_ = level.Debug(c.logger).Log("msg", fmt.Sprintf("Fetching metrics for registry namespace : %s", namespace.Name), "region", region)
+
+			go c.FetchNamespaceMetrics(ctx, &wg, ch, namespace)
+		}
+	}
+}
+
+func (c *RegistryCollector) FetchNamespaceMetrics(ctx context.Context, parentWg *sync.WaitGroup, ch chan<- prometheus.Metric, namespace *registry.Namespace) {
+	defer parentWg.Done()
+
+	labelsNamespace := []string{
+		namespace.ID,
+		namespace.Name,
+		namespace.Region.String(),
+		namespace.ProjectID,
+		fmt.Sprint(namespace.IsPublic),
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.NamespaceSize, prometheus.GaugeValue, float64(namespace.Size), labelsNamespace...)
+	ch <- prometheus.MustNewConstMetric(c.NamespaceImages, prometheus.GaugeValue, float64(namespace.ImageCount), labelsNamespace...)
+
+	response, err := c.registryClient.ListImages(&registry.ListImagesRequest{
+		Region:      namespace.Region,
+		NamespaceID: &namespace.ID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+
+	if err != nil {
+		c.errors.WithLabelValues("registry").Add(1)
+		_ = level.Warn(c.logger).Log(
+			"msg", "can't fetch the list of images for the registry namespace",
+			"region", namespace.Region,
+			"namespaceId", namespace.ID,
+			"namespaceName", namespace.Name,
+			"err", err,
+		)
+
+		return
+	}
+
+	for _, image := range response.Images {
+		visibility := image.Visibility
+
+		if visibility == registry.ImageVisibilityInherit {
+			visibility = registry.ImageVisibilityPrivate
+
+			if namespace.IsPublic {
+				visibility = registry.ImageVisibilityPublic
+			}
+		}
+
+		labelsImage := []string{
+			namespace.Name,
+			image.ID,
+			image.Name,
+			namespace.Region.String(),
+			namespace.ProjectID,
+			visibility.String(),
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.ImageSize, prometheus.GaugeValue, float64(image.Size), labelsImage...)
+		ch <- prometheus.MustNewConstMetric(c.ImageTags, prometheus.GaugeValue, float64(len(image.Tags)), labelsImage...)
+	}
+}
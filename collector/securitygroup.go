@@ -0,0 +1,176 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// SecurityGroupCollector collects metrics about all instance security groups.
+type SecurityGroupCollector struct {
+	logger         log.Logger
+	errors         *prometheus.CounterVec
+	client         *scw.Client
+	instanceClient *instance.API
+	timeout        time.Duration
+	zones          []scw.Zone
+	projects       ProjectFilter
+	tags           TagFilter
+	tagLabels      TagLabels
+
+	Info    *prometheus.Desc
+	Rules   *prometheus.Desc
+	Servers *prometheus.Desc
+}
+
+// NewSecurityGroupCollector returns a new SecurityGroupCollector.
+func NewSecurityGroupCollector(logger log.Logger, errors *prometheus.CounterVec, client *scw.Client, timeout time.Duration, zones []scw.Zone, projects ProjectFilter, tags TagFilter, tagLabels TagLabels) *SecurityGroupCollector {
+	errors.WithLabelValues("securitygroup").Add(0)
+
+	_ = level.Info(logger).Log("msg", "Security group collector enabled")
+
+	labels := []string{"id", "name", "zone", "project_id"}
+
+	return &SecurityGroupCollector{
+		logger:         logger,
+		errors:         errors,
+		client:         client,
+		instanceClient: instance.NewAPI(client),
+		timeout:        timeout,
+		zones:          zones,
+		projects:       projects,
+		tags:           tags,
+		tagLabels:      tagLabels,
+
+		Info: prometheus.NewDesc(
+			"scaleway_security_group_info",
+			"A metric with a constant '1' value labeled by the security group configuration",
+			append(append([]string{}, labels...), append([]string{"inbound_default_policy", "outbound_default_policy", "stateful", "project_default"}, tagLabels.Names()...)...), nil,
+		),
+		Rules: prometheus.NewDesc(
+			"scaleway_security_group_rules",
+			"The number of rules of the security group, per direction and action",
+			append(append([]string{}, labels...), "direction", "action"), nil,
+		),
+		Servers: prometheus.NewDesc(
+			"scaleway_security_group_servers",
+			"The number of servers attached to the security group",
+			labels, nil,
+		),
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+// collected by this Collector.
+func (c *SecurityGroupCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Info
+	ch <- c.Rules
+	ch <- c.Servers
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *SecurityGroupCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for _, zone := range c.zones {
+		response, err := c.instanceClient.ListSecurityGroups(&instance.ListSecurityGroupsRequest{Zone: zone}, scw.WithAllPages(), scw.WithContext(ctx))
+
+		if err != nil {
+			var responseError *scw.ResponseError
+
+			switch {
+			case errors.As(err, &responseError) && responseError.StatusCode == http.StatusNotImplemented:
+				_ = level.Debug(c.logger).Log("msg", "Instance is not supported in this zone", "zone", zone)
+			default:
+				c.errors.WithLabelValues("securitygroup").Add(1)
+				_ = level.Warn(c.logger).Log("msg", "can't fetch the list of security groups", "err", err, "zone", zone)
+			}
+
+			continue
+		}
+
+		_ = level.Debug(c.logger).Log("msg", fmt.Sprintf("found %d security groups", len(response.SecurityGroups)), "zone", zone)
+
+		for _, securityGroup := range response.SecurityGroups {
+			if !c.projects.Match(securityGroup.Project) || !c.tags.Match(securityGroup.Tags) {
+				continue
+			}
+
+			wg.Add(1)
+
+			go c.FetchSecurityGroupMetrics(ctx, &wg, ch, securityGroup)
+		}
+	}
+}
+
+func (c *SecurityGroupCollector) FetchSecurityGroupMetrics(ctx context.Context, parentWg *sync.WaitGroup, ch chan<- prometheus.Metric, securityGroup *instance.SecurityGroup) {
+	defer parentWg.Done()
+
+	labels := []string{securityGroup.ID, securityGroup.Name, securityGroup.Zone.String(), securityGroup.Project}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.Info,
+		prometheus.GaugeValue,
+		1.0,
+		append(
+			append(
+				append([]string{}, labels...),
+				securityGroup.InboundDefaultPolicy.String(),
+				securityGroup.OutboundDefaultPolicy.String(),
+				fmt.Sprint(securityGroup.Stateful),
+				fmt.Sprint(securityGroup.ProjectDefault),
+			),
+			c.tagLabels.Values(securityGroup.Tags)...,
+		)...,
+	)
+
+	ch <- prometheus.MustNewConstMetric(c.Servers, prometheus.GaugeValue, float64(len(securityGroup.Servers)), labels...)
+
+	rules, err := c.instanceClient.ListSecurityGroupRules(&instance.ListSecurityGroupRulesRequest{
+		Zone:            securityGroup.Zone,
+		SecurityGroupID: securityGroup.ID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+
+	if err != nil {
+		c.errors.WithLabelValues("securitygroup").Add(1)
+		_ = level.Warn(c.logger).Log(
+			"msg", "can't fetch the rules of the security group",
+			"zone", securityGroup.Zone,
+			"securityGroupId", securityGroup.ID,
+			"securityGroupName", securityGroup.Name,
+			"err", err,
+		)
+
+		return
+	}
+
+	counts := map[[2]string]float64{}
+
+	// always expose both directions and actions so a removed rule shows up as a 0 rather than a missing series
+	for _, direction := range []instance.SecurityGroupRuleDirection{instance.SecurityGroupRuleDirectionInbound, instance.SecurityGroupRuleDirectionOutbound} {
+		for _, action := range []instance.SecurityGroupRuleAction{instance.SecurityGroupRuleActionAccept, instance.SecurityGroupRuleActionDrop} {
+			counts[[2]string{direction.String(), action.String()}] = 0
+		}
+	}
+
+	for _, rule := range rules.Rules {
+		counts[[2]string{rule.Direction.String(), rule.Action.String()}]++
+	}
+
+	for key, count := range counts {
+		ch <- prometheus.MustNewConstMetric(c.Rules, prometheus.GaugeValue, count, append(append([]string{}, labels...), key[0], key[1])...)
+	}
+}
@@ -0,0 +1,36 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// SQSCredential is a read-only SQS credential of a project.
+type SQSCredential struct {
+	AccessKey string `json:"access_key"`
+	SecretKey string `json:"secret_key"`
+}
+
+// LoadSQSCredentials reads a JSON file mapping the project IDs to their SQS credential.
+func LoadSQSCredentials(path string) (map[string]SQSCredential, error) {
+	content, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, fmt.Errorf("can't read the SQS credentials file: %w", err)
+	}
+
+	var credentials map[string]SQSCredential
+
+	if err := json.Unmarshal(content, &credentials); err != nil {
+		return nil, fmt.Errorf("can't parse the SQS credentials file: %w", err)
+	}
+
+	for projectID, credential := range credentials {
+		if credential.AccessKey == "" || credential.SecretKey == "" {
+			return nil, fmt.Errorf("the SQS credential of the project %s is missing its access or secret key", projectID)
+		}
+	}
+
+	return credentials, nil
+}
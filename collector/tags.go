@@ -0,0 +1,97 @@
+package collector
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var labelNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`) //nolint:gochecknoglobals // compiled once
+
+// reservedTagLabelNames are the labels of the info metrics the tags can't be mapped to.
+var reservedTagLabelNames = map[string]bool{ //nolint:gochecknoglobals // constant set
+	"account": true, "id": true, "name": true, "region": true, "zone": true, "project_id": true, "type": true,
+	"engine": true, "version": true, "node_type": true, "ha_enabled": true, "backup_disabled": true,
+	"backup_frequency_hours": true, "backup_retention_days": true, "tags": true, "mode": true, "public": true,
+	"image": true, "cni": true, "state": true, "status": true, "volume_id": true, "volume_name": true, "model": true,
+	"private_network_id": true, "private_network_name": true, "policy_mode": true, "policy_type": true, "default": true,
+	"inbound_default_policy": true, "outbound_default_policy": true, "stateful": true, "project_default": true,
+}
+
+// TagLabel maps the value of a Scaleway tag to a Prometheus label.
+type TagLabel struct {
+	Tag   string
+	Label string
+}
+
+// TagLabels are the tags exposed as labels of the info metrics.
+type TagLabels []TagLabel
+
+// ParseTagLabels parses mappings written <tag>=label:<label>, e.g. env=label:environment.
+func ParseTagLabels(mappings []string) (TagLabels, error) {
+	tagLabels := make(TagLabels, 0, len(mappings))
+	labels := map[string]bool{}
+
+	for _, mapping := range mappings {
+		tag, target, found := strings.Cut(strings.TrimSpace(mapping), "=")
+
+		if !found || tag == "" || !strings.HasPrefix(target, "label:") {
+			return nil, fmt.Errorf("invalid tag mapping %q, expected <tag>=label:<label>", mapping)
+		}
+
+		label := strings.TrimPrefix(target, "label:")
+
+		switch {
+		case !labelNamePattern.MatchString(label) || strings.HasPrefix(label, "__"):
+			return nil, fmt.Errorf("invalid label name %q in the tag mapping %q", label, mapping)
+		case reservedTagLabelNames[label] || strings.HasPrefix(label, "tag_"):
+			return nil, fmt.Errorf("the label %q of the tag mapping %q is reserved", label, mapping)
+		case labels[label]:
+			return nil, fmt.Errorf("the label %q is mapped twice", label)
+		}
+
+		labels[label] = true
+
+		tagLabels = append(tagLabels, TagLabel{Tag: tag, Label: label})
+	}
+
+	return tagLabels, nil
+}
+
+// Names returns the names of the labels.
+func (t TagLabels) Names() []string {
+	names := make([]string, len(t))
+
+	for i, tagLabel := range t {
+		names[i] = tagLabel.Label
+	}
+
+	return names
+}
+
+// Values returns the values of the labels from Scaleway tags written <tag>=<value> or <tag>:<value>,
+// the value is empty when the tag is missing.
+func (t TagLabels) Values(tags []string) []string {
+	keyValues := make(map[string]string, len(tags))
+
+	for _, tag := range tags {
+		if key, value, found := strings.Cut(tag, "="); found {
+			keyValues[key] = value
+		} else if key, value, found = strings.Cut(tag, ":"); found {
+			keyValues[key] = value
+		}
+	}
+
+	return t.KeyValues(keyValues)
+}
+
+// KeyValues returns the values of the labels from key-value tags, e.g. the tags of the buckets.
+func (t TagLabels) KeyValues(tags map[string]string) []string {
+	values := make([]string, len(t))
+
+	for i, tagLabel := range t {
+		values[i] = tags[tagLabel.Tag]
+	}
+
+	return values
+}
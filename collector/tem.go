@@ -0,0 +1,176 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	tem "github.com/scaleway/scaleway-sdk-go/api/tem/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// TEMCollector collects metrics about all Transactional Email domains.
+type TEMCollector struct {
+	logger    log.Logger
+	errors    *prometheus.CounterVec
+	client    *scw.Client
+	temClient *tem.API
+	timeout   time.Duration
+	regions   []scw.Region
+	projects  ProjectFilter
+
+	DomainUp *prometheus.Desc
+	Emails   *prometheus.Desc
+}
+
+// NewTEMCollector returns a new TEMCollector.
+func NewTEMCollector(logger log.Logger, errors *prometheus.CounterVec, client *scw.Client, timeout time.Duration, regions []scw.Region, projects ProjectFilter) *TEMCollector {
+	errors.WithLabelValues("tem").Add(0)
+
+	_ = level.Info(logger).Log("msg", "TEM collector enabled")
+
+	return &TEMCollector{
+		logger:    logger,
+		errors:    errors,
+		client:    client,
+		temClient: tem.NewAPI(client),
+		timeout:   timeout,
+		regions:   regions,
+		projects:  projects,
+
+		DomainUp: prometheus.NewDesc(
+			"scaleway_tem_domain_up",
+			"If 1 the domain is checked and can send emails, 0.5 when the check is pending, 0 otherwise",
+			[]string{"id", "name", "region", "project_id", "status"}, nil,
+		),
+		Emails: prometheus.NewDesc(
+			"scaleway_tem_emails",
+			"The number of emails sent from the domain, per status",
+			[]string{"id", "name", "region", "project_id", "status"}, nil,
+		),
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+// collected by this Collector.
+func (c *TEMCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.DomainUp
+	ch <- c.Emails
+}
+
+// TEMStatistics are the statistics of the emails of a domain, including the blocked ones which are not yet part of the SDK.
+type TEMStatistics struct {
+	tem.Statistics
+
+	BlockedCount uint32 `json:"blocked_count"`
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *TEMCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for _, region := range c.regions {
+		response, err := c.temClient.ListDomains(&tem.ListDomainsRequest{Region: region}, scw.WithAllPages(), scw.WithContext(ctx))
+
+		if err != nil {
+			var responseError *scw.ResponseError
+
+			switch {
+			case errors.As(err, &responseError) && (responseError.StatusCode == http.StatusNotImplemented || responseError.StatusCode == http.StatusNotFound):
+				_ = level.Debug(c.logger).Log("msg", "TEM is not supported in this region", "region", region)
+			default:
+				c.errors.WithLabelValues("tem").Add(1)
+				_ = level.Warn(c.logger).Log("msg", "can't fetch the list of TEM domains", "err", err, "region", region)
+			}
+
+			continue
+		}
+
+		_ = level.Debug(c.logger).Log("msg", fmt.Sprintf("found %d TEM domains", len(response.Domains)), "region", region)
+
+		for _, domain := range response.Domains {
+			if !c.projects.Match(domain.ProjectID) {
+				continue
+			}
+
+			wg.Add(1)
+
+			go c.FetchDomainMetrics(ctx, &wg, ch, domain)
+		}
+	}
+}
+
+func (c *TEMCollector) FetchDomainMetrics(ctx context.Context, parentWg *sync.WaitGroup, ch chan<- prometheus.Metric, domain *tem.Domain) {
+	defer parentWg.Done()
+
+	var active float64
+
+	switch domain.Status {
+	case tem.DomainStatusChecked:
+		active = 1.0
+	case tem.DomainStatusPending:
+		active = 0.5
+	case tem.DomainStatusUnchecked:
+		active = 0.5
+	case tem.DomainStatusInvalid:
+		active = 0.0
+	case tem.DomainStatusLocked:
+		active = 0.0
+	case tem.DomainStatusRevoked:
+		active = 0.0
+	case tem.DomainStatusUnknown:
+		active = 0.0
+	default:
+		active = 0.0
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.DomainUp, prometheus.GaugeValue, active, domain.ID, domain.Name, domain.Region.String(), domain.ProjectID, domain.Status.String())
+
+	query := url.Values{}
+
+	query.Set("domain_id", domain.ID)
+
+	var statistics TEMStatistics
+
+	err := c.client.Do(&scw.ScalewayRequest{
+		Method:  "GET",
+		Path:    "/transactional-email/v1alpha1/regions/" + fmt.Sprint(domain.Region) + "/statistics",
+		Query:   query,
+		Headers: http.Header{},
+	}, &statistics, scw.WithContext(ctx))
+
+	if err != nil {
+		c.errors.WithLabelValues("tem").Add(1)
+		_ = level.Warn(c.logger).Log(
+			"msg", "can't fetch the statistics of the TEM domain",
+			"region", domain.Region,
+			"domainId", domain.ID,
+			"domainName", domain.Name,
+			"err", err,
+		)
+
+		return
+	}
+
+	for status, count := range map[string]uint32{
+		"new":      statistics.NewCount,
+		"sending":  statistics.SendingCount,
+		"sent":     statistics.SentCount,
+		"failed":   statistics.FailedCount,
+		"canceled": statistics.CanceledCount,
+		"blocked":  statistics.BlockedCount,
+	} {
+		ch <- prometheus.MustNewConstMetric(c.Emails, prometheus.GaugeValue, float64(count), domain.ID, domain.Name, domain.Region.String(), domain.ProjectID, status)
+	}
+}
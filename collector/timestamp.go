@@ -0,0 +1,16 @@
+package collector
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// withPointTimestamp dates the metric with the time of the given timeseries point when enabled,
+// otherwise the sample gets the time of the scrape although Scaleway may have measured it long before.
+func withPointTimestamp(metric prometheus.Metric, point *scw.TimeSeriesPoint, enabled bool) prometheus.Metric {
+	if !enabled {
+		return metric
+	}
+
+	return prometheus.NewMetricWithTimestamp(point.Timestamp, metric)
+}
@@ -0,0 +1,148 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// VPCCollector collects metrics about all VPCs and their private networks.
+type VPCCollector struct {
+	logger    log.Logger
+	errors    *prometheus.CounterVec
+	vpcClient *vpc.API
+	timeout   time.Duration
+	regions   []scw.Region
+	projects  ProjectFilter
+	tags      TagFilter
+	tagLabels TagLabels
+
+	PrivateNetworks           *prometheus.Desc
+	PrivateNetworkSubnets     *prometheus.Desc
+	PrivateNetworkDHCPEnabled *prometheus.Desc
+}
+
+// NewVPCCollector returns a new VPCCollector.
+func NewVPCCollector(logger log.Logger, errors *prometheus.CounterVec, client *scw.Client, timeout time.Duration, regions []scw.Region, projects ProjectFilter, tags TagFilter, tagLabels TagLabels) *VPCCollector {
+	errors.WithLabelValues("vpc").Add(0)
+
+	_ = level.Info(logger).Log("msg", "VPC collector enabled")
+
+	labelsPrivateNetwork := []string{"id", "name", "region", "project_id", "vpc_id"}
+
+	return &VPCCollector{
+		logger:    logger,
+		errors:    errors,
+		vpcClient: vpc.NewAPI(client),
+		timeout:   timeout,
+		regions:   regions,
+		projects:  projects,
+		tags:      tags,
+		tagLabels: tagLabels,
+
+		PrivateNetworks: prometheus.NewDesc(
+			"scaleway_vpc_private_networks",
+			"The number of private networks in the VPC",
+			append([]string{"id", "name", "region", "project_id", "default"}, tagLabels.Names()...), nil,
+		),
+		PrivateNetworkSubnets: prometheus.NewDesc(
+			"scaleway_vpc_private_network_subnets",
+			"The number of subnets of the private network",
+			labelsPrivateNetwork, nil,
+		),
+		PrivateNetworkDHCPEnabled: prometheus.NewDesc(
+			"scaleway_vpc_private_network_dhcp_enabled",
+			"If 1 the DHCP is enabled on the private network, 0 otherwise",
+			labelsPrivateNetwork, nil,
+		),
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+// collected by this Collector.
+func (c *VPCCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.PrivateNetworks
+	ch <- c.PrivateNetworkSubnets
+	ch <- c.PrivateNetworkDHCPEnabled
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *VPCCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	for _, region := range c.regions {
+		vpcs, err := c.vpcClient.ListVPCs(&vpc.ListVPCsRequest{Region: region}, scw.WithAllPages(), scw.WithContext(ctx))
+
+		if err != nil {
+			var responseError *scw.ResponseError
+
+			switch {
+			case errors.As(err, &responseError) && responseError.StatusCode == http.StatusNotImplemented:
+				_ = level.Debug(c.logger).Log("msg", "VPC is not supported in this region", "region", region)
+			default:
+				c.errors.WithLabelValues("vpc").Add(1)
+				_ = level.Warn(c.logger).Log("msg", "can't fetch the list of VPCs", "err", err, "region", region)
+			}
+
+			continue
+		}
+
+		_ = level.Debug(c.logger).Log("msg", fmt.Sprintf("found %d VPCs", len(vpcs.Vpcs)), "region", region)
+
+		for _, network := range vpcs.Vpcs {
+			if !c.projects.Match(network.ProjectID) || !c.tags.Match(network.Tags) {
+				continue
+			}
+
+			ch <- prometheus.MustNewConstMetric(
+				c.PrivateNetworks,
+				prometheus.GaugeValue,
+				float64(network.PrivateNetworkCount),
+				append([]string{network.ID, network.Name, network.Region.String(), network.ProjectID, fmt.Sprint(network.IsDefault)}, c.tagLabels.Values(network.Tags)...)...,
+			)
+		}
+
+		privateNetworks, err := c.vpcClient.ListPrivateNetworks(&vpc.ListPrivateNetworksRequest{Region: region}, scw.WithAllPages(), scw.WithContext(ctx))
+
+		if err != nil {
+			c.errors.WithLabelValues("vpc").Add(1)
+			_ = level.Warn(c.logger).Log("msg", "can't fetch the list of private networks", "err", err, "region", region)
+
+			continue
+		}
+
+		_ = level.Debug(c.logger).Log("msg", fmt.Sprintf("found %d private networks", len(privateNetworks.PrivateNetworks)), "region", region)
+
+		for _, privateNetwork := range privateNetworks.PrivateNetworks {
+			if !c.projects.Match(privateNetwork.ProjectID) || !c.tags.Match(privateNetwork.Tags) {
+				continue
+			}
+
+			labels := []string{
+				privateNetwork.ID,
+				privateNetwork.Name,
+				privateNetwork.Region.String(),
+				privateNetwork.ProjectID,
+				privateNetwork.VpcID,
+			}
+
+			var dhcpEnabled float64
+
+			if privateNetwork.DHCPEnabled {
+				dhcpEnabled = 1.0
+			}
+
+			ch <- prometheus.MustNewConstMetric(c.PrivateNetworkSubnets, prometheus.GaugeValue, float64(len(privateNetwork.Subnets)), labels...)
+			ch <- prometheus.MustNewConstMetric(c.PrivateNetworkDHCPEnabled, prometheus.GaugeValue, dhcpEnabled, labels...)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	arg "github.com/alexflint/go-arg"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"gopkg.in/yaml.v2"
+)
+
+// AccountConfig holds the credentials of an account scraped by the exporter, the accounts can only be defined in the configuration file.
+type AccountConfig struct {
+	Name            string   `yaml:"name"`
+	AccessKey       string   `yaml:"access_key"`
+	SecretKey       string   `yaml:"secret_key"`
+	SecretKeyFile   string   `yaml:"secret_key_file"`
+	OrganizationIDs []string `yaml:"organization_id"`
+}
+
+// DefaultConfig returns the configuration used when neither the flags, the environment variables nor the configuration file set a value.
+func DefaultConfig() Config {
+	return Config{
+		LogFormat:             "logfmt",
+		HTTPTimeout:           5000,
+		APIRateBurst:          10,
+		APIRetries:            2,
+		APIRetryMaxDelay:      2000,
+		ShutdownTimeout:       30000,
+		WebPath:               "/metrics",
+		MetricPrefix:          DefaultMetricPrefix,
+		WebAddr:               ":9503",
+		AccessLogFormat:       "logfmt",
+		AccessLogExcludePaths: []string{"/-/healthy"},
+	}
+}
+
+// LoadConfig reads the configuration from the flags, the environment variables and the configuration file given with --config or CONFIG_FILE,
+// the flags and the environment variables take precedence over the file.
+func LoadConfig() (Config, error) {
+	c := DefaultConfig()
+	ApplySDKEnvironment(&c)
+
+	parser, err := arg.NewParser(arg.Config{}, &c)
+
+	if err != nil {
+		return c, err
+	}
+
+	if errParse := parser.Parse(os.Args[1:]); errParse != nil {
+		return c, errParse
+	}
+
+	if c.ConfigFile != "" {
+		configFile := c.ConfigFile
+		c = DefaultConfig()
+
+		if errLoad := LoadConfigFile(configFile, &c); errLoad != nil {
+			return c, errLoad
+		}
+
+		ApplySDKEnvironment(&c)
+
+		if errParse := parser.Parse(os.Args[1:]); errParse != nil {
+			return c, errParse
+		}
+	}
+
+	if errCollectors := ResolveCollectors(&c); errCollectors != nil {
+		return c, errCollectors
+	}
+
+	return c, LoadSecretFiles(&c)
+}
+
+// ResolveCollectors applies the --collectors and --collectors.disable lists to the disable flags of the collectors,
+// the disable flags set directly are kept as deprecated aliases and listed in DeprecatedFlags.
+func ResolveCollectors(c *Config) error {
+	collectors := []struct {
+		name     string
+		disabled *bool
+	}{
+		{"applesilicon", &c.DisableAppleSiliconCollector},
+		{"billing", &c.DisableBillingCollector},
+		{"block", &c.DisableBlockCollector},
+		{"bucket", &c.DisableBucketCollector},
+		{"database", &c.DisableDatabaseCollector},
+		{"documentdb", &c.DisableDocumentDBCollector},
+		{"domain", &c.DisableDomainCollector},
+		{"edgeservices", &c.DisableEdgeServicesCollector},
+		{"iam", &c.DisableIAMCollector},
+		{"inference", &c.DisableInferenceCollector},
+		{"instance", &c.DisableInstanceCollector},
+		{"ipam", &c.DisableIPAMCollector},
+		{"kubernetes", &c.DisableKubernetesCollector},
+		{"loadbalancer", &c.DisableLoadBalancerCollector},
+		{"mnq", &c.DisableMNQCollector},
+		{"placementgroup", &c.DisablePlacementGroupCollector},
+		{"project", &c.DisableProjectCollector},
+		{"quota", &c.DisableQuotaCollector},
+		{"redis", &c.DisableRedisCollector},
+		{"registry", &c.DisableRegistryCollector},
+		{"securitygroup", &c.DisableSecurityGroupCollector},
+		{"tem", &c.DisableTEMCollector},
+		{"vpc", &c.DisableVPCCollector},
+	}
+
+	known := make(map[string]bool, len(collectors))
+
+	for _, collector := range collectors {
+		known[collector.name] = true
+	}
+
+	enabled := map[string]bool{}
+	disabled := map[string]bool{}
+
+	for _, name := range splitList(c.Collectors) {
+		if !known[name] {
+			return fmt.Errorf("unknown collector %s in --collectors", name)
+		}
+
+		enabled[name] = true
+	}
+
+	for _, name := range splitList(c.CollectorsDisable) {
+		if !known[name] {
+			return fmt.Errorf("unknown collector %s in --collectors.disable", name)
+		}
+
+		disabled[name] = true
+	}
+
+	c.DeprecatedFlags = nil
+
+	for _, collector := range collectors {
+		if *collector.disabled {
+			c.DeprecatedFlags = append(c.DeprecatedFlags, "--disable-"+collector.name+"-collector")
+		}
+
+		if (len(enabled) > 0 && !enabled[collector.name]) || disabled[collector.name] {
+			*collector.disabled = true
+		}
+	}
+
+	return nil
+}
+
+// ApplySDKEnvironment fills the config with the environment variables of the official Scaleway SDK (SCW_ACCESS_KEY, SCW_SECRET_KEY,
+// SCW_DEFAULT_REGION, SCW_DEFAULT_ZONE and SCW_DEFAULT_ORGANIZATION_ID), they take precedence over the configuration file
+// but not over the exporter specific SCALEWAY_* environment variables and the flags.
+func ApplySDKEnvironment(c *Config) {
+	if accessKey := os.Getenv(scw.ScwAccessKeyEnv); accessKey != "" {
+		c.ScalewayAccessKey = accessKey
+	}
+
+	if secretKey := os.Getenv(scw.ScwSecretKeyEnv); secretKey != "" {
+		c.ScalewaySecretKey = secretKey
+	}
+
+	if region := os.Getenv(scw.ScwDefaultRegionEnv); region != "" {
+		c.ScalewayRegion = scw.Region(region)
+	}
+
+	if zone := os.Getenv(scw.ScwDefaultZoneEnv); zone != "" {
+		c.ScalewayZone = scw.Zone(zone)
+	}
+
+	if organizationID := os.Getenv(scw.ScwDefaultOrganizationIDEnv); organizationID != "" {
+		c.ScalewayOrganizationIDs = []string{organizationID}
+	}
+
+	if apiURL := os.Getenv(scw.ScwAPIURLEnv); apiURL != "" {
+		c.ScalewayAPIURL = apiURL
+	}
+}
+
+// splitList splits the comma separated items of a list, so --collectors=a,b and --collectors a b are equivalent.
+func splitList(items []string) []string {
+	var list []string
+
+	for _, item := range items {
+		for _, name := range strings.Split(item, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				list = append(list, name)
+			}
+		}
+	}
+
+	return list
+}
+
+// LoadSecretFiles reads the secrets given as files (e.g. SCALEWAY_SECRET_KEY_FILE) so they can be mounted from Kubernetes or Swarm secrets,
+// a secret file takes precedence over the secret given directly.
+func LoadSecretFiles(c *Config) error {
+	secrets := []struct {
+		path   string
+		secret *string
+	}{
+		{c.ScalewayAccessKeyFile, &c.ScalewayAccessKey},
+		{c.ScalewaySecretKeyFile, &c.ScalewaySecretKey},
+		{c.LoadBalancerCockpitTokenFile, &c.LoadBalancerCockpitToken},
+		{c.ReloadTokenFile, &c.ReloadToken},
+	}
+
+	for i := range c.Accounts {
+		secrets = append(secrets, struct {
+			path   string
+			secret *string
+		}{c.Accounts[i].SecretKeyFile, &c.Accounts[i].SecretKey})
+	}
+
+	for _, secret := range secrets {
+		if secret.path == "" {
+			continue
+		}
+
+		content, err := os.ReadFile(secret.path)
+
+		if err != nil {
+			return fmt.Errorf("can't read the secret file: %w", err)
+		}
+
+		*secret.secret = strings.TrimSpace(string(content))
+	}
+
+	return nil
+}
+
+// MustLoadConfig is like LoadConfig but prints the usage and exits when the configuration is invalid.
+func MustLoadConfig() Config {
+	c, err := LoadConfig()
+
+	if err == nil {
+		return c
+	}
+
+	parser, errParser := arg.NewParser(arg.Config{}, &c)
+
+	if errParser != nil {
+		_, _ = os.Stderr.WriteString(errParser.Error() + "\n")
+		os.Exit(1)
+	}
+
+	switch {
+	case errors.Is(err, arg.ErrHelp):
+		parser.WriteHelp(os.Stdout)
+		os.Exit(0)
+	default:
+		parser.Fail(err.Error())
+	}
+
+	return c
+}
+
+// LoadConfigFile reads a YAML configuration file into the config, its keys are the environment variables in lower case
+// (e.g. scaleway_access_key) and the disable flags in snake case (e.g. disable_billing_collector).
+func LoadConfigFile(path string, c *Config) error {
+	content, err := os.ReadFile(path)
+
+	if err != nil {
+		return fmt.Errorf("can't read the configuration file: %w", err)
+	}
+
+	if errParse := yaml.UnmarshalStrict(content, c); errParse != nil {
+		return fmt.Errorf("can't parse the configuration file: %w", errParse)
+	}
+
+	return nil
+}
@@ -4,15 +4,15 @@ import (
 	"net/http"
 	"os"
 	"runtime"
+	"strings"
 	"time"
 
-	arg "github.com/alexflint/go-arg"
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	"github.com/joho/godotenv"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/exporter-toolkit/web"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/yoannma/scaleway_exporter/collector"
 )
@@ -36,74 +36,107 @@ var (
 
 // Config gets its content from env and passes it on to different packages.
 type Config struct {
-	Debug                        bool       `arg:"env:DEBUG"`
-	ScalewayAccessKey            string     `arg:"env:SCALEWAY_ACCESS_KEY"`
-	ScalewaySecretKey            string     `arg:"env:SCALEWAY_SECRET_KEY"`
-	ScalewayRegion               scw.Region `arg:"env:SCALEWAY_REGION"`
-	ScalewayZone                 scw.Zone   `arg:"env:SCALEWAY_ZONE"`
-	ScalewayOrganizationID       string     `arg:"env:SCALEWAY_ORGANIZATION_ID"`
-	HTTPTimeout                  int        `arg:"env:HTTP_TIMEOUT"`
-	WebAddr                      string     `arg:"env:WEB_ADDR"`
-	WebPath                      string     `arg:"env:WEB_PATH"`
-	DisableBillingCollector      bool       `arg:"--disable-billing-collector"`
-	DisableBucketCollector       bool       `arg:"--disable-bucket-collector"`
-	DisableDatabaseCollector     bool       `arg:"--disable-database-collector"`
-	DisableLoadBalancerCollector bool       `arg:"--disable-loadbalancer-collector"`
-	DisableRedisCollector        bool       `arg:"--disable-redis-collector"`
+	ConfigFile                     string          `arg:"--config,env:CONFIG_FILE" yaml:"-"`
+	Debug                          bool            `arg:"env:DEBUG" yaml:"debug"`
+	LogFormat                      string          `arg:"--log.format,env:LOG_FORMAT" yaml:"log_format"`
+	ScalewayAccessKey              string          `arg:"env:SCALEWAY_ACCESS_KEY" yaml:"scaleway_access_key"`
+	ScalewayAccessKeyFile          string          `arg:"--scaleway-access-key-file,env:SCALEWAY_ACCESS_KEY_FILE" yaml:"scaleway_access_key_file"`
+	ScalewaySecretKey              string          `arg:"env:SCALEWAY_SECRET_KEY" yaml:"scaleway_secret_key"`
+	ScalewaySecretKeyFile          string          `arg:"--scaleway-secret-key-file,env:SCALEWAY_SECRET_KEY_FILE" yaml:"scaleway_secret_key_file"`
+	ScalewayRegion                 scw.Region      `arg:"env:SCALEWAY_REGION" yaml:"scaleway_region"`
+	ScalewayZone                   scw.Zone        `arg:"env:SCALEWAY_ZONE" yaml:"scaleway_zone"`
+	ScalewayOrganizationIDs        []string        `arg:"--organization-id,env:SCALEWAY_ORGANIZATION_ID" yaml:"scaleway_organization_id"`
+	ScalewayProjectIDs             []string        `arg:"--project-id,env:SCALEWAY_PROJECT_ID" yaml:"scaleway_project_id"`
+	IncludeTags                    []string        `arg:"--include-tag,env:INCLUDE_TAGS" yaml:"include_tags"`
+	ExcludeTags                    []string        `arg:"--exclude-tag,env:EXCLUDE_TAGS" yaml:"exclude_tags"`
+	ScalewayAPIURL                 string          `arg:"--api-url,env:SCALEWAY_API_URL" yaml:"scaleway_api_url"`
+	HTTPTimeout                    int             `arg:"env:HTTP_TIMEOUT" yaml:"http_timeout"`
+	CacheTTL                       int             `arg:"--cache-ttl,env:CACHE_TTL" yaml:"cache_ttl"`
+	APIRateLimit                   float64         `arg:"--api-rate-limit,env:API_RATE_LIMIT" yaml:"api_rate_limit"`
+	APIRateBurst                   int             `arg:"--api-rate-burst,env:API_RATE_BURST" yaml:"api_rate_burst"`
+	ProxyURL                       string          `arg:"--proxy-url,env:PROXY_URL" yaml:"proxy_url"`
+	APIRetries                     int             `arg:"--api-retries,env:API_RETRIES" yaml:"api_retries"`
+	APIRetryMaxDelay               int             `arg:"--api-retry-max-delay,env:API_RETRY_MAX_DELAY" yaml:"api_retry_max_delay"`
+	CollectionInterval             int             `arg:"--collection-interval,env:COLLECTION_INTERVAL" yaml:"collection_interval"`
+	MetricPrefix                   string          `arg:"--metric-prefix,env:METRIC_PREFIX" yaml:"metric_prefix"`
+	WebAddr                        string          `arg:"env:WEB_ADDR" yaml:"web_addr"`
+	WebPath                        string          `arg:"env:WEB_PATH" yaml:"web_path"`
+	WebConfigFile                  string          `arg:"--web.config.file,env:WEB_CONFIG_FILE" yaml:"web_config_file"`
+	PprofAddr                      string          `arg:"--pprof-addr,env:PPROF_ADDR" yaml:"pprof_addr"`
+	ShutdownTimeout                int             `arg:"--shutdown-timeout,env:SHUTDOWN_TIMEOUT" yaml:"shutdown_timeout"`
+	AccessLog                      bool            `arg:"--access-log,env:ACCESS_LOG" yaml:"access_log"`
+	AccessLogFormat                string          `arg:"--access-log-format,env:ACCESS_LOG_FORMAT" yaml:"access_log_format"`
+	AccessLogExcludePaths          []string        `arg:"--access-log-exclude-path,env:ACCESS_LOG_EXCLUDE_PATHS" yaml:"access_log_exclude_paths"`
+	BillingCurrency                string          `arg:"--billing-currency,env:BILLING_CURRENCY" yaml:"billing_currency"`
+	BillingCurrencyRatesFile       string          `arg:"--billing-currency-rates-file,env:BILLING_CURRENCY_RATES_FILE" yaml:"billing_currency_rates_file"`
+	BillingBudgetsFile             string          `arg:"--billing-budgets-file,env:BILLING_BUDGETS_FILE" yaml:"billing_budgets_file"`
+	TagLabels                      []string        `arg:"--tag-label,env:TAG_LABELS" yaml:"tag_labels"`
+	BucketTagLabels                []string        `arg:"--bucket-tag-label,env:BUCKET_TAG_LABELS" yaml:"bucket_tag_labels"`
+	S3Endpoint                     string          `arg:"--s3-endpoint,env:S3_ENDPOINT" yaml:"s3_endpoint"`
+	BucketAllProjects              bool            `arg:"--bucket-all-projects,env:BUCKET_ALL_PROJECTS" yaml:"bucket_all_projects"`
+	BucketProbes                   []string        `arg:"--bucket-probe,env:BUCKET_PROBES" yaml:"bucket_probes"`
+	ExposeUnmappedMetrics          bool            `arg:"--expose-unmapped-metrics,env:EXPOSE_UNMAPPED_METRICS" yaml:"expose_unmapped_metrics"`
+	MetricTimestamps               bool            `arg:"--metric-timestamps,env:METRIC_TIMESTAMPS" yaml:"metric_timestamps"`
+	LoadBalancerCockpitURL         string          `arg:"--loadbalancer-cockpit-url,env:LOADBALANCER_COCKPIT_URL" yaml:"loadbalancer_cockpit_url"`
+	LoadBalancerCockpitToken       string          `arg:"--loadbalancer-cockpit-token,env:LOADBALANCER_COCKPIT_TOKEN" yaml:"loadbalancer_cockpit_token"`
+	LoadBalancerCockpitTokenFile   string          `arg:"--loadbalancer-cockpit-token-file,env:LOADBALANCER_COCKPIT_TOKEN_FILE" yaml:"loadbalancer_cockpit_token_file"`
+	MNQCredentialsFile             string          `arg:"--mnq-credentials-file,env:MNQ_CREDENTIALS_FILE" yaml:"mnq_credentials_file"`
+	ReloadToken                    string          `arg:"--reload-token,env:RELOAD_TOKEN" yaml:"reload_token"`
+	ReloadTokenFile                string          `arg:"--reload-token-file,env:RELOAD_TOKEN_FILE" yaml:"reload_token_file"`
+	Accounts                       []AccountConfig `arg:"-" yaml:"accounts"`
+	Collectors                     []string        `arg:"--collectors,env:COLLECTORS" yaml:"collectors"`
+	CollectorsDisable              []string        `arg:"--collectors.disable,env:COLLECTORS_DISABLE" yaml:"collectors_disable"`
+	DeprecatedFlags                []string        `arg:"-" yaml:"-"`
+	DisableAppleSiliconCollector   bool            `arg:"--disable-applesilicon-collector" yaml:"disable_applesilicon_collector" help:"deprecated, use --collectors.disable=applesilicon"`
+	DisableBillingCollector        bool            `arg:"--disable-billing-collector" yaml:"disable_billing_collector" help:"deprecated, use --collectors.disable=billing"`
+	DisableBlockCollector          bool            `arg:"--disable-block-collector" yaml:"disable_block_collector" help:"deprecated, use --collectors.disable=block"`
+	DisableBucketCollector         bool            `arg:"--disable-bucket-collector" yaml:"disable_bucket_collector" help:"deprecated, use --collectors.disable=bucket"`
+	DisableDatabaseCollector       bool            `arg:"--disable-database-collector" yaml:"disable_database_collector" help:"deprecated, use --collectors.disable=database"`
+	DisableDocumentDBCollector     bool            `arg:"--disable-documentdb-collector" yaml:"disable_documentdb_collector" help:"deprecated, use --collectors.disable=documentdb"`
+	DisableDomainCollector         bool            `arg:"--disable-domain-collector" yaml:"disable_domain_collector" help:"deprecated, use --collectors.disable=domain"`
+	DisableEdgeServicesCollector   bool            `arg:"--disable-edgeservices-collector" yaml:"disable_edgeservices_collector" help:"deprecated, use --collectors.disable=edgeservices"`
+	DisableIAMCollector            bool            `arg:"--disable-iam-collector" yaml:"disable_iam_collector" help:"deprecated, use --collectors.disable=iam"`
+	DisableInferenceCollector      bool            `arg:"--disable-inference-collector" yaml:"disable_inference_collector" help:"deprecated, use --collectors.disable=inference"`
+	DisableInstanceCollector       bool            `arg:"--disable-instance-collector" yaml:"disable_instance_collector" help:"deprecated, use --collectors.disable=instance"`
+	DisableIPAMCollector           bool            `arg:"--disable-ipam-collector" yaml:"disable_ipam_collector" help:"deprecated, use --collectors.disable=ipam"`
+	DisableKubernetesCollector     bool            `arg:"--disable-kubernetes-collector" yaml:"disable_kubernetes_collector" help:"deprecated, use --collectors.disable=kubernetes"`
+	DisableLoadBalancerCollector   bool            `arg:"--disable-loadbalancer-collector" yaml:"disable_loadbalancer_collector" help:"deprecated, use --collectors.disable=loadbalancer"`
+	DisableMNQCollector            bool            `arg:"--disable-mnq-collector" yaml:"disable_mnq_collector" help:"deprecated, use --collectors.disable=mnq"`
+	DisablePlacementGroupCollector bool            `arg:"--disable-placementgroup-collector" yaml:"disable_placementgroup_collector" help:"deprecated, use --collectors.disable=placementgroup"`
+	DisableProjectCollector        bool            `arg:"--disable-project-collector" yaml:"disable_project_collector" help:"deprecated, use --collectors.disable=project"`
+	DisableQuotaCollector          bool            `arg:"--disable-quota-collector" yaml:"disable_quota_collector" help:"deprecated, use --collectors.disable=quota"`
+	DisableRedisCollector          bool            `arg:"--disable-redis-collector" yaml:"disable_redis_collector" help:"deprecated, use --collectors.disable=redis"`
+	DisableRegistryCollector       bool            `arg:"--disable-registry-collector" yaml:"disable_registry_collector" help:"deprecated, use --collectors.disable=registry"`
+	DisableSecurityGroupCollector  bool            `arg:"--disable-securitygroup-collector" yaml:"disable_securitygroup_collector" help:"deprecated, use --collectors.disable=securitygroup"`
+	DisableTEMCollector            bool            `arg:"--disable-tem-collector" yaml:"disable_tem_collector" help:"deprecated, use --collectors.disable=tem"`
+	DisableVPCCollector            bool            `arg:"--disable-vpc-collector" yaml:"disable_vpc_collector" help:"deprecated, use --collectors.disable=vpc"`
 }
 
 func main() {
 	_ = godotenv.Load()
 
-	c := Config{
-		HTTPTimeout:                  5000,
-		WebPath:                      "/metrics",
-		WebAddr:                      ":9503",
-		DisableBillingCollector:      false,
-		DisableBucketCollector:       false,
-		DisableDatabaseCollector:     false,
-		DisableLoadBalancerCollector: false,
-	}
-	arg.MustParse(&c)
+	c := MustLoadConfig()
 
 	filterOption := level.AllowInfo()
 	if c.Debug {
 		filterOption = level.AllowDebug()
 	}
 
-	logger := log.NewLogfmtLogger(log.NewSyncWriter(os.Stderr))
+	var logger log.Logger
+
+	switch c.LogFormat {
+	case "json":
+		logger = log.NewJSONLogger(log.NewSyncWriter(os.Stderr))
+	default:
+		logger = log.NewLogfmtLogger(log.NewSyncWriter(os.Stderr))
+	}
+
 	logger = level.NewFilter(logger, filterOption)
 	logger = log.With(logger,
 		"ts", log.DefaultTimestampUTC,
 		"caller", log.DefaultCaller,
 	)
 
-	if c.ScalewayAccessKey == "" {
-		_ = level.Error(logger).Log("msg", "Scaleway Access Key is required")
-		os.Exit(1)
-	}
-
-	if c.ScalewaySecretKey == "" {
-		_ = level.Error(logger).Log("msg", "Scaleway Secret Key is required")
-		os.Exit(1)
-	}
-
-	var regions []scw.Region
-	if c.ScalewayRegion == "" {
-		_ = level.Info(logger).Log("msg", "Scaleway Region is set to ALL")
-		regions = scw.AllRegions
-	} else {
-		regions = []scw.Region{c.ScalewayRegion}
-	}
-
-	var zones []scw.Zone
-	if c.ScalewayZone == "" {
-		_ = level.Info(logger).Log("msg", "Scaleway Zone is set to ALL")
-		zones = scw.AllZones
-	} else {
-		zones = []scw.Zone{c.ScalewayZone}
-	}
-
 	_ = level.Info(logger).Log(
 		"msg", "starting scaleway_exporter",
 		"version", Version,
@@ -112,53 +145,64 @@ func main() {
 		"goVersion", GoVersion,
 	)
 
-	client, err := scw.NewClient(
-		// Get your credentials at https://console.scaleway.com/account/credentials
-		scw.WithDefaultRegion(regions[0]),
-		scw.WithAuth(c.ScalewayAccessKey, c.ScalewaySecretKey),
-	)
-
-	if err != nil {
-		_ = level.Error(logger).Log("msg", "Scaleway client initialization error", "err", err)
-		os.Exit(1)
+	if len(c.DeprecatedFlags) > 0 {
+		_ = level.Warn(logger).Log("msg", "the --disable-*-collector flags are deprecated, use --collectors or --collectors.disable instead", "flags", strings.Join(c.DeprecatedFlags, ","))
 	}
 
-	timeout := time.Duration(c.HTTPTimeout) * time.Millisecond
-
-	errors := prometheus.NewCounterVec(prometheus.CounterOpts{
-		Name: "scaleway_errors_total",
-		Help: "The total number of errors per collector",
-	}, []string{"collector"})
-
 	r := prometheus.NewRegistry()
 	r.MustRegister(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
 	r.MustRegister(collectors.NewGoCollector())
-	r.MustRegister(errors)
 	r.MustRegister(collector.NewExporterCollector(logger, Version, Revision, BuildDate, GoVersion, StartTime))
 
-	if !c.DisableBillingCollector && c.ScalewayOrganizationID != "" {
-		r.MustRegister(collector.NewBillingCollector(logger, errors, client, timeout, c.ScalewayOrganizationID))
+	collectorRegistry, err := NewCollectorRegistry(logger, c)
+
+	if err != nil {
+		_ = level.Error(logger).Log("msg", "can't create the collectors", "err", err)
+		os.Exit(1)
 	}
 
-	if !c.DisableBucketCollector {
-		r.MustRegister(collector.NewBucketCollector(logger, errors, client, timeout, regions))
+	reloader := NewReloader(logger, collectorRegistry)
+
+	go reloader.WatchSignal()
+
+	var (
+		gatherer prometheus.Gatherer = reloader
+		selector CollectorSelector   = reloader
+	)
+
+	if c.CollectionInterval > 0 {
+		background := NewBackgroundGatherer(logger, reloader, time.Duration(c.CollectionInterval)*time.Millisecond)
+
+		r.MustRegister(background.Duration, background.Timestamp)
+
+		go background.Run()
+
+		gatherer = background
+		selector = nil
 	}
 
-	if !c.DisableDatabaseCollector {
-		r.MustRegister(collector.NewDatabaseCollector(logger, errors, client, timeout, regions))
+	if c.PprofAddr != "" {
+		go ServePprof(logger, c.PprofAddr)
 	}
 
-	if !c.DisableLoadBalancerCollector {
-		r.MustRegister(collector.NewLoadBalancerCollector(logger, errors, client, timeout, zones))
+	mux := http.NewServeMux()
+
+	if !ValidMetricPrefix(c.MetricPrefix) {
+		_ = level.Error(logger).Log("msg", "invalid metric prefix", "prefix", c.MetricPrefix)
+		os.Exit(1)
 	}
 
-	if !c.DisableRedisCollector {
-		r.MustRegister(collector.NewRedisCollector(logger, errors, client, timeout, zones))
+	mux.Handle(c.WebPath, MetricsHandler(r, gatherer, selector, c.MetricPrefix))
+
+	if c.ReloadToken != "" {
+		mux.Handle("/-/reload", reloader.Handler(c.ReloadToken))
 	}
 
-	http.Handle(c.WebPath, promhttp.HandlerFor(r, promhttp.HandlerOpts{}))
+	mux.HandleFunc("/-/healthy", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("OK"))
+	})
 
-	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		_, _ = w.Write([]byte(`<html>
 			<head><title>Scaleway Exporter</title></head>
 			<body>
@@ -168,14 +212,26 @@ func main() {
 			</html>`))
 	})
 
-	_ = level.Info(logger).Log("msg", "listening", "addr", c.WebAddr)
+	if c.WebConfigFile == "" && !c.DisableBillingCollector {
+		_ = level.Warn(logger).Log("msg", "the billing metrics are exposed without TLS nor authentication, set --web.config.file to enable them")
+	}
 
 	server := &http.Server{
-		Addr:              c.WebAddr,
 		ReadHeaderTimeout: 5 * time.Second,
+		Handler: AccessLogHandler(mux, r, AccessLogOptions{
+			Enabled:      c.AccessLog,
+			Format:       c.AccessLogFormat,
+			ExcludePaths: c.AccessLogExcludePaths,
+		}),
 	}
 
-	err = server.ListenAndServe()
+	systemdSocket := false
+
+	err = ListenAndServeUntilSignal(logger, server, &web.FlagConfig{
+		WebListenAddresses: &[]string{c.WebAddr},
+		WebSystemdSocket:   &systemdSocket,
+		WebConfigFile:      &c.WebConfigFile,
+	}, time.Duration(c.ShutdownTimeout)*time.Millisecond)
 
 	if err != nil {
 		_ = level.Error(logger).Log("msg", "http ListenAndServe error", "err", err)
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// CollectorSelector returns the gatherer of some of the collectors.
+type CollectorSelector interface {
+	Gatherer(names []string) (prometheus.Gatherer, error)
+}
+
+// MetricsHandler serves the metrics of the exporter and of the collectors with the given metric prefix,
+// a scrape can select the collectors with the collect[] parameter (e.g. ?collect[]=database&collect[]=billing)
+// unless the selector is nil.
+func MetricsHandler(exporter prometheus.Gatherer, collectors prometheus.Gatherer, selector CollectorSelector, prefix string) http.Handler {
+	handler := func(gatherer prometheus.Gatherer) http.Handler {
+		if prefix != DefaultMetricPrefix {
+			gatherer = NewPrefixGatherer(gatherer, prefix)
+		}
+
+		return promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{})
+	}
+
+	all := handler(prometheus.Gatherers{exporter, collectors})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		names := req.URL.Query()["collect[]"]
+
+		if len(names) == 0 {
+			all.ServeHTTP(w, req)
+
+			return
+		}
+
+		if selector == nil {
+			http.Error(w, "collect[] is not supported when the collectors run in the background", http.StatusBadRequest)
+
+			return
+		}
+
+		gatherer, err := selector.Gatherer(names)
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		handler(prometheus.Gatherers{exporter, gatherer}).ServeHTTP(w, req)
+	})
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	account "github.com/scaleway/scaleway-sdk-go/api/account/v2"
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// DetectOrganizationID resolves the organization owning the API key used by the client,
+// either from its bearer (user or application) or from its default project.
+func DetectOrganizationID(client *scw.Client) (string, error) {
+	accessKey, ok := client.GetAccessKey()
+
+	if !ok {
+		return "", errors.New("the client has no access key")
+	}
+
+	iamClient := iam.NewAPI(client)
+
+	apiKey, err := iamClient.GetAPIKey(&iam.GetAPIKeyRequest{AccessKey: accessKey})
+
+	if err != nil {
+		return "", fmt.Errorf("can't fetch the API key: %w", err)
+	}
+
+	switch {
+	case apiKey.UserID != nil:
+		user, errUser := iamClient.GetUser(&iam.GetUserRequest{UserID: *apiKey.UserID})
+
+		if errUser == nil {
+			return user.OrganizationID, nil
+		}
+	case apiKey.ApplicationID != nil:
+		application, errApplication := iamClient.GetApplication(&iam.GetApplicationRequest{ApplicationID: *apiKey.ApplicationID})
+
+		if errApplication == nil {
+			return application.OrganizationID, nil
+		}
+	}
+
+	if apiKey.DefaultProjectID == "" {
+		return "", errors.New("can't resolve the bearer of the API key and it has no default project")
+	}
+
+	project, err := account.NewAPI(client).GetProject(&account.GetProjectRequest{ProjectID: apiKey.DefaultProjectID})
+
+	if err != nil {
+		return "", fmt.Errorf("can't fetch the default project of the API key: %w", err)
+	}
+
+	return project.OrganizationID, nil
+}
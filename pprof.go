@@ -0,0 +1,34 @@
+package main
+
+import (
+	"net/http"
+	"net/http/pprof"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+)
+
+// ServePprof serves the net/http/pprof endpoints under /debug/pprof on their own listener,
+// so they are never exposed on the metrics port.
+func ServePprof(logger log.Logger, addr string) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	server := &http.Server{
+		Addr:              addr,
+		ReadHeaderTimeout: 5 * time.Second,
+		Handler:           mux,
+	}
+
+	_ = level.Info(logger).Log("msg", "serving the pprof endpoints", "address", addr)
+
+	if err := server.ListenAndServe(); err != nil {
+		_ = level.Error(logger).Log("msg", "can't serve the pprof endpoints", "err", err)
+	}
+}
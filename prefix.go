@@ -0,0 +1,62 @@
+package main
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// DefaultMetricPrefix is the prefix of the metrics of the exporter.
+const DefaultMetricPrefix = "scaleway_"
+
+var metricPrefixPattern = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`) //nolint:gochecknoglobals // compiled once
+
+// ValidMetricPrefix tells whether the prefix makes valid metric names.
+func ValidMetricPrefix(prefix string) bool {
+	return metricPrefixPattern.MatchString(prefix)
+}
+
+// PrefixGatherer replaces the scaleway_ prefix of the gathered metrics by another one.
+type PrefixGatherer struct {
+	gatherer prometheus.Gatherer
+	prefix   string
+}
+
+// NewPrefixGatherer returns a new PrefixGatherer renaming the metrics of the given gatherer.
+func NewPrefixGatherer(gatherer prometheus.Gatherer, prefix string) *PrefixGatherer {
+	return &PrefixGatherer{gatherer: gatherer, prefix: prefix}
+}
+
+// Gather implements the prometheus.Gatherer interface, the families are copied so the ones
+// of the wrapped gatherer, e.g. a background snapshot, are left untouched.
+func (g *PrefixGatherer) Gather() ([]*dto.MetricFamily, error) {
+	families, err := g.gatherer.Gather()
+
+	renamed := make([]*dto.MetricFamily, 0, len(families))
+
+	for _, family := range families {
+		if !strings.HasPrefix(family.GetName(), DefaultMetricPrefix) {
+			renamed = append(renamed, family)
+
+			continue
+		}
+
+		name := g.prefix + strings.TrimPrefix(family.GetName(), DefaultMetricPrefix)
+
+		renamed = append(renamed, &dto.MetricFamily{
+			Name:   &name,
+			Help:   family.Help,
+			Type:   family.Type,
+			Metric: family.Metric,
+		})
+	}
+
+	sort.Slice(renamed, func(i, j int) bool {
+		return renamed[i].GetName() < renamed[j].GetName()
+	})
+
+	return renamed, err
+}
@@ -0,0 +1,364 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/yoannma/scaleway_exporter/collector"
+)
+
+// CollectorRegistry holds a registry per collector, so a scrape can select the collectors it gathers,
+// and a registry of the metrics shared by the collectors (e.g. the errors counter).
+type CollectorRegistry struct {
+	shared     *prometheus.Registry
+	collectors map[string]*prometheus.Registry
+}
+
+// Registerer returns the registerer of the metrics of the named collector, or of the shared metrics when the name is empty.
+func (r *CollectorRegistry) Registerer(name string) prometheus.Registerer {
+	if name == "" {
+		return r.shared
+	}
+
+	registry, ok := r.collectors[name]
+
+	if !ok {
+		registry = prometheus.NewRegistry()
+		r.collectors[name] = registry
+	}
+
+	return registry
+}
+
+// Gather implements the prometheus.Gatherer interface, it gathers all the collectors.
+func (r *CollectorRegistry) Gather() ([]*dto.MetricFamily, error) {
+	gatherers := prometheus.Gatherers{r.shared}
+
+	for _, registry := range r.collectors {
+		gatherers = append(gatherers, registry)
+	}
+
+	return gatherers.Gather()
+}
+
+// Gatherer returns the gatherer of the named collectors along with the shared metrics,
+// it fails when one of the collectors is unknown or disabled.
+func (r *CollectorRegistry) Gatherer(names []string) (prometheus.Gatherer, error) {
+	gatherers := prometheus.Gatherers{r.shared}
+	selected := map[string]bool{}
+
+	for _, name := range names {
+		if selected[name] {
+			continue
+		}
+
+		selected[name] = true
+
+		registry, ok := r.collectors[name]
+
+		if !ok {
+			return nil, fmt.Errorf("the collector %s is unknown or disabled", name)
+		}
+
+		gatherers = append(gatherers, registry)
+	}
+
+	return gatherers, nil
+}
+
+// NewCollectorRegistry returns a registry holding the collectors of each account of the config,
+// their metrics are labeled with the name of the account when several accounts are defined.
+func NewCollectorRegistry(logger log.Logger, c Config) (*CollectorRegistry, error) {
+	r := &CollectorRegistry{
+		shared:     prometheus.NewRegistry(),
+		collectors: map[string]*prometheus.Registry{},
+	}
+
+	if len(c.Accounts) == 0 {
+		return r, RegisterCollectors(logger, r.Registerer, c)
+	}
+
+	names := map[string]bool{}
+
+	for _, account := range c.Accounts {
+		if account.Name == "" {
+			return nil, errors.New("the name of the accounts is required")
+		}
+
+		if names[account.Name] {
+			return nil, fmt.Errorf("the account %s is defined twice", account.Name)
+		}
+
+		names[account.Name] = true
+
+		accountConfig := c
+		accountConfig.ScalewayAccessKey = account.AccessKey
+		accountConfig.ScalewaySecretKey = account.SecretKey
+		accountConfig.ScalewayOrganizationIDs = account.OrganizationIDs
+
+		labels := prometheus.Labels{"account": account.Name}
+
+		registerer := func(name string) prometheus.Registerer {
+			return prometheus.WrapRegistererWith(labels, r.Registerer(name))
+		}
+
+		if err := RegisterCollectors(log.With(logger, "account", account.Name), registerer, accountConfig); err != nil {
+			return nil, fmt.Errorf("can't register the collectors of the account %s: %w", account.Name, err)
+		}
+	}
+
+	return r, nil
+}
+
+// RegisterCollectors creates the Scaleway client described by the config and registers its enabled collectors
+// with the registerer returned for their name, the shared metrics are registered with the one returned for an empty name.
+func RegisterCollectors(logger log.Logger, registerer func(name string) prometheus.Registerer, c Config) error {
+	r := registerer("")
+
+	if c.ScalewayAccessKey == "" {
+		return errors.New("Scaleway Access Key is required")
+	}
+
+	if c.ScalewaySecretKey == "" {
+		return errors.New("Scaleway Secret Key is required")
+	}
+
+	var regions []scw.Region
+	if c.ScalewayRegion == "" {
+		_ = level.Info(logger).Log("msg", "Scaleway Region is set to ALL")
+		regions = scw.AllRegions
+	} else {
+		regions = []scw.Region{c.ScalewayRegion}
+	}
+
+	var zones []scw.Zone
+	if c.ScalewayZone == "" {
+		_ = level.Info(logger).Log("msg", "Scaleway Zone is set to ALL")
+		zones = scw.AllZones
+	} else {
+		zones = []scw.Zone{c.ScalewayZone}
+	}
+
+	limiter := NewAPILimiter(c.APIRateLimit, c.APIRateBurst)
+
+	transport, err := NewAPITransport(c.ProxyURL)
+
+	if err != nil {
+		return err
+	}
+
+	clientOptions := []scw.ClientOption{
+		// Get your credentials at https://console.scaleway.com/account/credentials
+		scw.WithDefaultRegion(regions[0]),
+		scw.WithAuth(c.ScalewayAccessKey, c.ScalewaySecretKey),
+		scw.WithHTTPClient(NewInstrumentedHTTPClient(r, transport, limiter, c.APIRetries, time.Duration(c.APIRetryMaxDelay)*time.Millisecond)),
+	}
+
+	if c.ScalewayAPIURL != "" {
+		clientOptions = append(clientOptions, scw.WithAPIURL(strings.TrimSuffix(c.ScalewayAPIURL, "/")))
+	}
+
+	client, err := scw.NewClient(clientOptions...)
+
+	if err != nil {
+		return fmt.Errorf("Scaleway client initialization error: %w", err)
+	}
+
+	if len(c.ScalewayOrganizationIDs) == 0 {
+		organizationID, errDetect := DetectOrganizationID(client)
+
+		if errDetect != nil {
+			_ = level.Warn(logger).Log(
+				"msg", "can't detect the organization ID, set SCALEWAY_ORGANIZATION_ID to enable the billing, IAM, project and quota collectors",
+				"err", errDetect,
+			)
+		} else {
+			_ = level.Info(logger).Log("msg", "organization ID detected from the API key", "organizationId", organizationID)
+			c.ScalewayOrganizationIDs = []string{organizationID}
+		}
+	}
+
+	timeout := time.Duration(c.HTTPTimeout) * time.Millisecond
+
+	billingOptions := collector.BillingOptions{Currency: strings.ToUpper(c.BillingCurrency)}
+
+	if billingOptions.Currency != "" {
+		if c.BillingCurrencyRatesFile == "" {
+			return errors.New("a currency rates file is required to convert the billing consumptions")
+		}
+
+		rates, errRates := collector.LoadStaticCurrencyRates(c.BillingCurrencyRatesFile)
+
+		if errRates != nil {
+			return fmt.Errorf("can't load the currency rates: %w", errRates)
+		}
+
+		billingOptions.Rates = rates
+	}
+
+	if c.BillingBudgetsFile != "" {
+		budgets, errBudgets := collector.LoadBudgets(c.BillingBudgetsFile)
+
+		if errBudgets != nil {
+			return fmt.Errorf("can't load the billing budgets: %w", errBudgets)
+		}
+
+		billingOptions.Budgets = budgets
+	}
+
+	errorCounter := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "scaleway_errors_total",
+		Help: "The total number of errors per collector",
+	}, []string{"collector"})
+
+	r.MustRegister(errorCounter)
+
+	projects := collector.ProjectFilter(c.ScalewayProjectIDs)
+
+	tags := collector.TagFilter{Include: c.IncludeTags, Exclude: c.ExcludeTags}
+
+	tagLabels, errTagLabels := collector.ParseTagLabels(c.TagLabels)
+
+	if errTagLabels != nil {
+		return errTagLabels
+	}
+
+	cache := collector.NewListingCache(time.Duration(c.CacheTTL) * time.Millisecond)
+
+	if !c.DisableAppleSiliconCollector {
+		registerer("applesilicon").MustRegister(collector.NewAppleSiliconCollector(logger, errorCounter, client, timeout, zones, projects))
+	}
+
+	if !c.DisableBillingCollector && len(c.ScalewayOrganizationIDs) > 0 {
+		registerer("billing").MustRegister(collector.NewBillingCollector(logger, errorCounter, client, timeout, c.ScalewayOrganizationIDs, billingOptions))
+	}
+
+	if !c.DisableBlockCollector {
+		registerer("block").MustRegister(collector.NewBlockCollector(logger, errorCounter, client, timeout, zones, projects, tags, tagLabels))
+	}
+
+	// the S3 and SQS clients keep the default client of the AWS SDK unless their requests must be limited or proxied
+	var awsHTTPClient *http.Client
+
+	if limiter != nil || c.ProxyURL != "" {
+		awsHTTPClient = &http.Client{Transport: NewRateLimitedTransport(transport, limiter)}
+	}
+
+	if !c.DisableBucketCollector {
+		bucketProbes, errBucketProbes := collector.ParseBucketProbes(c.BucketProbes)
+
+		if errBucketProbes != nil {
+			return errBucketProbes
+		}
+
+		bucketOptions := collector.BucketOptions{TagLabels: c.BucketTagLabels, MappedTagLabels: tagLabels, Endpoint: c.S3Endpoint, Projects: projects, Timestamps: c.MetricTimestamps, HTTPClient: awsHTTPClient, Probes: bucketProbes}
+
+		if c.BucketAllProjects {
+			bucketOptions.OrganizationIDs = c.ScalewayOrganizationIDs
+		}
+
+		registerer("bucket").MustRegister(collector.NewBucketCollector(logger, errorCounter, client, timeout, regions, cache, bucketOptions))
+	}
+
+	if !c.DisableDatabaseCollector {
+		registerer("database").MustRegister(collector.NewDatabaseCollector(logger, errorCounter, client, timeout, regions, projects, tags, cache, c.ExposeUnmappedMetrics, c.MetricTimestamps, tagLabels))
+	}
+
+	if !c.DisableDocumentDBCollector {
+		registerer("documentdb").MustRegister(collector.NewDocumentDBCollector(logger, errorCounter, client, timeout, regions, projects, tags, c.MetricTimestamps, tagLabels))
+	}
+
+	if !c.DisableDomainCollector {
+		registerer("domain").MustRegister(collector.NewDomainCollector(logger, errorCounter, client, timeout, projects))
+	}
+
+	if !c.DisableEdgeServicesCollector {
+		registerer("edgeservices").MustRegister(collector.NewEdgeServicesCollector(logger, errorCounter, client, timeout, projects))
+	}
+
+	if !c.DisableIAMCollector && len(c.ScalewayOrganizationIDs) > 0 {
+		registerer("iam").MustRegister(collector.NewIAMCollector(logger, errorCounter, client, timeout, c.ScalewayOrganizationIDs))
+	}
+
+	if !c.DisableInferenceCollector {
+		registerer("inference").MustRegister(collector.NewInferenceCollector(logger, errorCounter, client, timeout, regions, projects, tags, tagLabels))
+	}
+
+	if !c.DisableInstanceCollector {
+		registerer("instance").MustRegister(collector.NewInstanceCollector(logger, errorCounter, client, timeout, zones, projects, tags, cache, tagLabels))
+	}
+
+	if !c.DisableIPAMCollector {
+		registerer("ipam").MustRegister(collector.NewIPAMCollector(logger, errorCounter, client, timeout, regions, projects, tags, tagLabels))
+	}
+
+	if !c.DisableKubernetesCollector {
+		registerer("kubernetes").MustRegister(collector.NewKubernetesCollector(logger, errorCounter, client, timeout, regions, projects, tags, cache, tagLabels))
+	}
+
+	if !c.DisableLoadBalancerCollector {
+		registerer("loadbalancer").MustRegister(collector.NewLoadBalancerCollector(logger, errorCounter, client, timeout, zones, projects, tags, cache, c.ExposeUnmappedMetrics, c.MetricTimestamps, collector.LoadBalancerOptions{
+			CockpitURL:   c.LoadBalancerCockpitURL,
+			CockpitToken: c.LoadBalancerCockpitToken,
+			TagLabels:    tagLabels,
+		}))
+	}
+
+	if !c.DisableMNQCollector {
+		mnqOptions := collector.MNQOptions{HTTPClient: awsHTTPClient}
+
+		if c.MNQCredentialsFile != "" {
+			credentials, errCredentials := collector.LoadSQSCredentials(c.MNQCredentialsFile)
+
+			if errCredentials != nil {
+				return fmt.Errorf("can't load the SQS credentials: %w", errCredentials)
+			}
+
+			mnqOptions.Credentials = credentials
+		}
+
+		registerer("mnq").MustRegister(collector.NewMNQCollector(logger, errorCounter, client, timeout, regions, projects, mnqOptions))
+	}
+
+	if !c.DisablePlacementGroupCollector {
+		registerer("placementgroup").MustRegister(collector.NewPlacementGroupCollector(logger, errorCounter, client, timeout, zones, projects, tags, tagLabels))
+	}
+
+	if !c.DisableProjectCollector && len(c.ScalewayOrganizationIDs) > 0 {
+		registerer("project").MustRegister(collector.NewProjectCollector(logger, errorCounter, client, timeout, regions, zones, projects, c.ScalewayOrganizationIDs))
+	}
+
+	if !c.DisableQuotaCollector && len(c.ScalewayOrganizationIDs) > 0 {
+		registerer("quota").MustRegister(collector.NewQuotaCollector(logger, errorCounter, client, timeout, zones, c.ScalewayOrganizationIDs))
+	}
+
+	if !c.DisableRedisCollector {
+		registerer("redis").MustRegister(collector.NewRedisCollector(logger, errorCounter, client, timeout, zones, projects, tags, cache, c.ExposeUnmappedMetrics, c.MetricTimestamps, tagLabels))
+	}
+
+	if !c.DisableRegistryCollector {
+		registerer("registry").MustRegister(collector.NewRegistryCollector(logger, errorCounter, client, timeout, regions, projects))
+	}
+
+	if !c.DisableSecurityGroupCollector {
+		registerer("securitygroup").MustRegister(collector.NewSecurityGroupCollector(logger, errorCounter, client, timeout, zones, projects, tags, tagLabels))
+	}
+
+	if !c.DisableTEMCollector {
+		registerer("tem").MustRegister(collector.NewTEMCollector(logger, errorCounter, client, timeout, regions, projects))
+	}
+
+	if !c.DisableVPCCollector {
+		registerer("vpc").MustRegister(collector.NewVPCCollector(logger, errorCounter, client, timeout, regions, projects, tags, tagLabels))
+	}
+
+	return nil
+}
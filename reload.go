@@ -0,0 +1,110 @@
+package main
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// Reloader gathers the metrics of the current collectors registry and replaces it when the configuration is reloaded.
+type Reloader struct {
+	logger   log.Logger
+	mutex    sync.RWMutex
+	registry *CollectorRegistry
+}
+
+// NewReloader returns a new Reloader gathering the given collectors registry.
+func NewReloader(logger log.Logger, registry *CollectorRegistry) *Reloader {
+	return &Reloader{
+		logger:   logger,
+		registry: registry,
+	}
+}
+
+// Gather implements the prometheus.Gatherer interface.
+func (r *Reloader) Gather() ([]*dto.MetricFamily, error) {
+	r.mutex.RLock()
+	registry := r.registry
+	r.mutex.RUnlock()
+
+	return registry.Gather()
+}
+
+// Gatherer returns the gatherer of the named collectors of the current collectors registry.
+func (r *Reloader) Gatherer(names []string) (prometheus.Gatherer, error) {
+	r.mutex.RLock()
+	registry := r.registry
+	r.mutex.RUnlock()
+
+	return registry.Gatherer(names)
+}
+
+// Reload reads the configuration again and replaces the collectors registry,
+// the current one is kept when the new configuration is invalid.
+func (r *Reloader) Reload() error {
+	c, err := LoadConfig()
+
+	if err != nil {
+		return err
+	}
+
+	registry, err := NewCollectorRegistry(r.logger, c)
+
+	if err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	r.registry = registry
+	r.mutex.Unlock()
+
+	_ = level.Info(r.logger).Log("msg", "configuration reloaded")
+
+	return nil
+}
+
+// WatchSignal reloads the configuration each time the process receives a SIGHUP.
+func (r *Reloader) WatchSignal() {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+
+	for range hup {
+		if err := r.Reload(); err != nil {
+			_ = level.Error(r.logger).Log("msg", "can't reload the configuration", "err", err)
+		}
+	}
+}
+
+// Handler returns the handler of the /-/reload endpoint, the requests must be authenticated with the token as a bearer.
+func (r *Reloader) Handler(token string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost && req.Method != http.MethodPut {
+			http.Error(w, "only POST or PUT requests allowed", http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		if subtle.ConstantTimeCompare([]byte(req.Header.Get("Authorization")), []byte("Bearer "+token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+
+			return
+		}
+
+		if err := r.Reload(); err != nil {
+			_ = level.Error(r.logger).Log("msg", "can't reload the configuration", "err", err)
+			http.Error(w, "can't reload the configuration: "+err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		_, _ = w.Write([]byte("OK"))
+	})
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/exporter-toolkit/web"
+)
+
+// ListenAndServeUntilSignal serves the HTTP server until the process receives a SIGINT or a SIGTERM,
+// the server then stops accepting scrapes and waits up to the drain timeout for the in-flight ones to complete.
+func ListenAndServeUntilSignal(logger log.Logger, server *http.Server, flags *web.FlagConfig, drainTimeout time.Duration) error {
+	served := make(chan error, 1)
+
+	go func() {
+		served <- web.ListenAndServe(server, flags, logger)
+	}()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+
+	defer signal.Stop(stop)
+
+	select {
+	case err := <-served:
+		return err
+	case sig := <-stop:
+		_ = level.Info(logger).Log("msg", "shutting down, draining the in-flight scrapes", "signal", sig.String(), "timeout", drainTimeout)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		return fmt.Errorf("can't drain the in-flight scrapes: %w", err)
+	}
+
+	if err := <-served; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	_ = level.Info(logger).Log("msg", "shutdown complete")
+
+	return nil
+}
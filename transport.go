@@ -0,0 +1,252 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+)
+
+// InstrumentedTransport counts and times the requests sent to the Scaleway API,
+// the service is the first segment of the path of the request, e.g. rdb or instance.
+type InstrumentedTransport struct {
+	next     http.RoundTripper
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+// NewInstrumentedTransport returns a new InstrumentedTransport sending the requests with next,
+// its metrics are registered in the given registry.
+func NewInstrumentedTransport(next http.RoundTripper, registry prometheus.Registerer) *InstrumentedTransport {
+	t := &InstrumentedTransport{
+		next: next,
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "scaleway_api_requests_total",
+			Help: "The total number of requests sent to the Scaleway API",
+		}, []string{"service", "method", "code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "scaleway_api_request_duration_seconds",
+			Help:    "The duration of the requests sent to the Scaleway API",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"service", "method"}),
+	}
+
+	registry.MustRegister(t.requests, t.duration)
+
+	return t
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *InstrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	service := apiService(req)
+
+	start := time.Now()
+
+	resp, err := t.next.RoundTrip(req)
+
+	t.duration.WithLabelValues(service, req.Method).Observe(time.Since(start).Seconds())
+
+	code := "error"
+
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+
+	t.requests.WithLabelValues(service, req.Method, code).Inc()
+
+	return resp, err
+}
+
+// RateLimitedTransport waits for the limiter before sending each request, the requests are sent
+// right away when the limiter is nil.
+type RateLimitedTransport struct {
+	next    http.RoundTripper
+	limiter *rate.Limiter
+}
+
+// NewRateLimitedTransport returns a new RateLimitedTransport sending the requests with next.
+func NewRateLimitedTransport(next http.RoundTripper, limiter *rate.Limiter) *RateLimitedTransport {
+	return &RateLimitedTransport{next: next, limiter: limiter}
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *RateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.limiter != nil {
+		if err := t.limiter.Wait(req.Context()); err != nil {
+			return nil, fmt.Errorf("API rate limit: %w", err)
+		}
+	}
+
+	return t.next.RoundTrip(req)
+}
+
+// RetryTransport sends again the requests failing with a transport error, a 429 or a 5xx status,
+// waiting for an exponential backoff with jitter capped to maxDelay between the attempts.
+type RetryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+	retries    *prometheus.CounterVec
+}
+
+// NewRetryTransport returns a new RetryTransport sending the requests with next,
+// its metrics are registered in the given registry.
+func NewRetryTransport(next http.RoundTripper, maxRetries int, maxDelay time.Duration, registry prometheus.Registerer) *RetryTransport {
+	t := &RetryTransport{
+		next:       next,
+		maxRetries: maxRetries,
+		baseDelay:  100 * time.Millisecond,
+		maxDelay:   maxDelay,
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "scaleway_api_retries_total",
+			Help: "The total number of requests sent again to the Scaleway API after a transient failure",
+		}, []string{"service", "code"}),
+	}
+
+	registry.MustRegister(t.retries)
+
+	return t
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+
+	for attempt := 0; attempt < t.maxRetries && retryable(resp, err); attempt++ {
+		if req.Body != nil && req.GetBody == nil {
+			break
+		}
+
+		code := "error"
+		delay := t.backoff(attempt)
+
+		if err == nil {
+			code = strconv.Itoa(resp.StatusCode)
+
+			if seconds, errParse := strconv.Atoi(resp.Header.Get("Retry-After")); errParse == nil && seconds >= 0 {
+				delay = time.Duration(seconds) * time.Second
+
+				if delay > t.maxDelay {
+					delay = t.maxDelay
+				}
+			}
+
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		retry := req.Clone(req.Context())
+
+		if req.GetBody != nil {
+			body, errBody := req.GetBody()
+
+			if errBody != nil {
+				return nil, fmt.Errorf("can't rewind the request body: %w", errBody)
+			}
+
+			retry.Body = body
+		}
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		t.retries.WithLabelValues(apiService(req), code).Inc()
+
+		resp, err = t.next.RoundTrip(retry)
+	}
+
+	return resp, err
+}
+
+// backoff returns the delay before the given retry, a random duration up to the exponential backoff.
+func (t *RetryTransport) backoff(attempt int) time.Duration {
+	delay := t.baseDelay << attempt
+
+	if delay <= 0 || delay > t.maxDelay {
+		delay = t.maxDelay
+	}
+
+	return time.Duration(rand.Int63n(int64(delay) + 1)) //nolint:gosec // the jitter doesn't need a secure random
+}
+
+// retryable tells whether a request failed with a transient error worth a retry.
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
+
+// apiService returns the Scaleway service of a request, the first segment of its path.
+func apiService(req *http.Request) string {
+	return strings.SplitN(strings.TrimPrefix(req.URL.Path, "/"), "/", 2)[0]
+}
+
+// NewAPILimiter returns the limiter shared by the requests of the collectors, or nil when requestsPerSecond is not positive.
+func NewAPILimiter(requestsPerSecond float64, burst int) *rate.Limiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+
+	if burst < 1 {
+		burst = 1
+	}
+
+	return rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+}
+
+// NewAPITransport returns a transport with the same settings as the default one of the Scaleway SDK, sending the requests
+// through the proxy given by its URL, or the one of the HTTP_PROXY, HTTPS_PROXY and NO_PROXY variables when empty.
+func NewAPITransport(proxyURL string) (*http.Transport, error) {
+	proxy := http.ProxyFromEnvironment
+
+	if proxyURL != "" {
+		parsed, err := url.Parse(proxyURL)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+
+		if parsed.Scheme == "" || parsed.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %s: a scheme and a host are required", proxyURL)
+		}
+
+		proxy = http.ProxyURL(parsed)
+	}
+
+	return &http.Transport{
+		Proxy:                 proxy,
+		DialContext:           (&net.Dialer{Timeout: 5 * time.Second}).DialContext,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ResponseHeaderTimeout: 30 * time.Second,
+		MaxIdleConnsPerHost:   20,
+	}, nil
+}
+
+// NewInstrumentedHTTPClient returns the HTTP client used by the Scaleway client, sending the requests with the given transport,
+// instrumented, limited by the given limiter and retried on transient failures.
+func NewInstrumentedHTTPClient(registry prometheus.Registerer, base http.RoundTripper, limiter *rate.Limiter, maxRetries int, maxRetryDelay time.Duration) *http.Client {
+	transport := NewInstrumentedTransport(base, registry)
+
+	return &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: NewRetryTransport(NewRateLimitedTransport(transport, limiter), maxRetries, maxRetryDelay, registry),
+	}
+}